@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+// runAPIKey implements `apikey create -name name [-roles a,b]|list|revoke id`.
+// The key is printed once on creation, only its hash is stored.
+func runAPIKey(ctx context.Context, keys store.APIKeyStoreInterface, args []string) error {
+	const usage = "usage: apikey create -name name [-roles a,b] | list | revoke id"
+	if len(args) == 0 {
+		return fmt.Errorf(usage)
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		name := flags.String("name", "", "what the key is for, recorded as the actor of its writes")
+		roles := flags.String("roles", auth.RoleViewer, "comma separated roles of the key: viewer, editor or admin")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := models.ValidateAPIKeyName(*name); err != nil {
+			return err
+		}
+		keyRoles := splitRoles(*roles)
+		if err := auth.ValidateRoles(keyRoles); err != nil {
+			return err
+		}
+
+		plain, key, err := auth.GenerateAPIKey(strings.TrimSpace(*name), keyRoles)
+		if err != nil {
+			return err
+		}
+		if _, err := keys.CreateAPIKey(ctx, key); err != nil {
+			return err
+		}
+		fmt.Printf("created api key %s (%s)\n", key.ID, key.Name)
+		fmt.Println("store it now, it cannot be shown again:")
+		fmt.Println(plain)
+		return nil
+
+	case "list":
+		list, err := keys.ListAPIKeys(ctx)
+		if err != nil {
+			return err
+		}
+		for _, key := range list {
+			state := "active"
+			if key.RevokedAt != nil {
+				state = "revoked " + key.RevokedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%s  %s  %-20s  %-20s  %s\n", key.ID, key.Prefix, key.Name, strings.Join(key.Roles, ","), state)
+		}
+		return nil
+
+	case "revoke":
+		if len(args) != 2 {
+			return fmt.Errorf(usage)
+		}
+		key, err := keys.RevokeAPIKey(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("revoked api key %s (%s)\n", key.ID, key.Name)
+		return nil
+	}
+
+	return fmt.Errorf("unknown apikey command %q", args[0])
+}
+
+func splitRoles(raw string) []string {
+	roles := []string{}
+	for _, role := range strings.Split(raw, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
@@ -0,0 +1,239 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NotFoundError is returned when the requested record does not exist.
+type NotFoundError struct {
+	Entity string
+	ID     string
+}
+
+func NewNotFound(entity string, id string) *NotFoundError {
+	return &NotFoundError{Entity: entity, ID: id}
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s with id %s not found", e.Entity, e.ID)
+}
+
+// FieldError describes one invalid field of a request. Field is the dotted
+// json path of the field, e.g. engine.displacement.
+type FieldError struct {
+	Field   string `json:"field"`
+	Pointer string `json:"pointer,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError is returned when a request is well formed but its
+// content breaks a business rule. Fields lists every violation found.
+type ValidationError struct {
+	Message string
+	Fields  []FieldError
+}
+
+func NewValidation(message string) *ValidationError {
+	return &ValidationError{Message: message}
+}
+
+func NewFieldValidation(fields []FieldError) *ValidationError {
+	return &ValidationError{Message: "request validation failed", Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return e.Message + ": " + strings.Join(messages, "; ")
+}
+
+// FieldPointer turns a dotted field path into a json pointer (RFC 6901).
+func FieldPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		parts[i] = strings.NewReplacer("~", "~0", "/", "~1").Replace(part)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// ConflictError is returned when the request clashes with the current
+// state of the data. Details, when set, are sent back to the client.
+type ConflictError struct {
+	Message string
+	Details map[string]any
+}
+
+func NewConflict(message string) *ConflictError {
+	return &ConflictError{Message: message}
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// InvalidIDError is returned when an id is empty or not a valid uuid.
+type InvalidIDError struct {
+	ID  string
+	Err error
+}
+
+func NewInvalidID(id string, err error) *InvalidIDError {
+	return &InvalidIDError{ID: id, Err: err}
+}
+
+func (e *InvalidIDError) Error() string {
+	if e.ID == "" {
+		return "id cannot be empty"
+	}
+	return fmt.Sprintf("invalid id %q", e.ID)
+}
+
+func (e *InvalidIDError) Unwrap() error {
+	return e.Err
+}
+
+// BadRequestError is returned when the request itself cannot be read,
+// for example a body that is not valid json. Fields lists the parameters
+// and body values that do not match the api schema, when that is why.
+type BadRequestError struct {
+	Message string
+	Err     error
+	Fields  []FieldError
+}
+
+func NewBadRequest(message string, err error) *BadRequestError {
+	return &BadRequestError{Message: message, Err: err}
+}
+
+func NewSchemaViolation(fields []FieldError) *BadRequestError {
+	return &BadRequestError{Message: "request does not match the api schema", Fields: fields}
+}
+
+func (e *BadRequestError) Error() string {
+	if len(e.Fields) > 0 {
+		messages := make([]string, 0, len(e.Fields))
+		for _, field := range e.Fields {
+			messages = append(messages, field.Field+": "+field.Message)
+		}
+		return e.Message + ": " + strings.Join(messages, "; ")
+	}
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *BadRequestError) Unwrap() error {
+	return e.Err
+}
+
+// UnauthorizedError is returned when the caller could not be identified.
+type UnauthorizedError struct {
+	Message string
+}
+
+func NewUnauthorized(message string) *UnauthorizedError {
+	return &UnauthorizedError{Message: message}
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Message
+}
+
+// ForbiddenError is returned when the caller is known but lacks the
+// permission an operation needs.
+type ForbiddenError struct {
+	Permission string
+}
+
+func NewForbidden(permission string) *ForbiddenError {
+	return &ForbiddenError{Permission: permission}
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("missing permission %s", e.Permission)
+}
+
+// PreconditionFailedError is returned when a write carries an If-Match
+// that no longer matches the record, because someone else changed it.
+type PreconditionFailedError struct {
+	Entity string
+	ID     string
+}
+
+func NewPreconditionFailed(entity string, id string) *PreconditionFailedError {
+	return &PreconditionFailedError{Entity: entity, ID: id}
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("%s with id %s was modified since it was read", e.Entity, e.ID)
+}
+
+// UnsupportedMediaTypeError is returned when the request body comes in a
+// format the endpoint does not accept.
+type UnsupportedMediaTypeError struct {
+	MediaType string
+	Accepted  []string
+}
+
+func NewUnsupportedMediaType(mediaType string, accepted ...string) *UnsupportedMediaTypeError {
+	return &UnsupportedMediaTypeError{MediaType: mediaType, Accepted: accepted}
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported media type %q, use one of %s", e.MediaType, strings.Join(e.Accepted, ", "))
+}
+
+// NotAcceptableError is returned when none of the formats a client
+// accepts can be produced.
+type NotAcceptableError struct {
+	Accept   string
+	Produced []string
+}
+
+func NewNotAcceptable(accept string, produced ...string) *NotAcceptableError {
+	return &NotAcceptableError{Accept: accept, Produced: produced}
+}
+
+func (e *NotAcceptableError) Error() string {
+	return fmt.Sprintf("cannot produce %q, use one of %s", e.Accept, strings.Join(e.Produced, ", "))
+}
+
+// Is* helpers look through wrapped errors.
+
+func IsNotFound(err error) bool {
+	var target *NotFoundError
+	return errors.As(err, &target)
+}
+
+func IsValidation(err error) bool {
+	var target *ValidationError
+	return errors.As(err, &target)
+}
+
+func IsConflict(err error) bool {
+	var target *ConflictError
+	return errors.As(err, &target)
+}
+
+func IsForbidden(err error) bool {
+	var target *ForbiddenError
+	return errors.As(err, &target)
+}
+
+func IsPreconditionFailed(err error) bool {
+	var target *PreconditionFailedError
+	return errors.As(err, &target)
+}
@@ -0,0 +1,26 @@
+package apperrors
+
+// GraphQLError maps an error to the message and extensions of the GraphQL
+// error reporting it. It goes through ToProblem like GRPCStatus, so the
+// extensions carry the problem code and status the REST api would answer
+// with, its details and the invalid fields.
+func GraphQLError(err error) (string, map[string]any) {
+	problem := ToProblem(err)
+
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+
+	extensions := map[string]any{
+		"code":   problem.Code,
+		"status": problem.Status,
+	}
+	if len(problem.Details) > 0 {
+		extensions["details"] = problem.Details
+	}
+	if len(problem.Errors) > 0 {
+		extensions["errors"] = problem.Errors
+	}
+	return message, extensions
+}
@@ -0,0 +1,81 @@
+package apperrors
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain is the domain of the ErrorInfo of gRPC errors.
+const ErrorDomain = "car-management"
+
+// GRPCStatus maps an error to the status the gRPC api answers with. It
+// goes through ToProblem so both apis report an error alike: the problem
+// code is the reason of an ErrorInfo detail, the problem details its
+// metadata, and the invalid fields become a BadRequest detail.
+func GRPCStatus(err error) *status.Status {
+	problem := ToProblem(err)
+
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+
+	info := &errdetails.ErrorInfo{Reason: problem.Code, Domain: ErrorDomain}
+	for key, value := range problem.Details {
+		if info.Metadata == nil {
+			info.Metadata = map[string]string{}
+		}
+		info.Metadata[key] = fmt.Sprint(value)
+	}
+	details := []protoadapt.MessageV1{info}
+
+	if fields := withPointers(problem.Errors); len(fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+				Reason:      field.Code,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	st := status.New(GRPCCode(problem.Status), message)
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		log.Println("Error adding the error details: ", detailsErr)
+		return st
+	}
+	return withDetails
+}
+
+// GRPCCode is the gRPC code of an HTTP status of the taxonomy.
+func GRPCCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusUnsupportedMediaType, http.StatusNotAcceptable:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.FailedPrecondition
+	case http.StatusPreconditionFailed:
+		// the record changed since it was read, read it again and retry
+		return codes.Aborted
+	case http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
+	case http.StatusInternalServerError:
+		return codes.Internal
+	}
+	return codes.Unknown
+}
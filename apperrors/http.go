@@ -0,0 +1,123 @@
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// Problem is the json body sent for every error, shaped after RFC 7807.
+type Problem struct {
+	Type    string         `json:"type"`
+	Title   string         `json:"title"`
+	Status  int            `json:"status"`
+	Code    string         `json:"code"`
+	Detail  string         `json:"detail,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+	Errors  []FieldError   `json:"errors,omitempty"`
+}
+
+// ToProblem maps an error to its status code and response body. Errors
+// outside the taxonomy are reported as a 500 without leaking their text.
+func ToProblem(err error) Problem {
+	var (
+		notFound      *NotFoundError
+		validation    *ValidationError
+		conflict      *ConflictError
+		invalidID     *InvalidIDError
+		badRequest    *BadRequestError
+		unauthorized  *UnauthorizedError
+		forbidden     *ForbiddenError
+		mediaType     *UnsupportedMediaTypeError
+		notAcceptable *NotAcceptableError
+		precondition  *PreconditionFailedError
+		tooLarge      *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return newProblem(http.StatusNotFound, "not_found", notFound.Error())
+	case errors.As(err, &validation):
+		problem := newProblem(http.StatusUnprocessableEntity, "validation_failed", validation.Message)
+		problem.Errors = withPointers(validation.Fields)
+		return problem
+	case errors.As(err, &conflict):
+		problem := newProblem(http.StatusConflict, "conflict", conflict.Error())
+		problem.Details = conflict.Details
+		return problem
+	case errors.As(err, &invalidID):
+		return newProblem(http.StatusBadRequest, "invalid_id", invalidID.Error())
+	case errors.As(err, &tooLarge):
+		// checked before bad requests, which may wrap it
+		return newProblem(http.StatusRequestEntityTooLarge, "request_too_large", fmt.Sprintf("the request body is larger than %d bytes", tooLarge.Limit))
+	case errors.As(err, &badRequest):
+		if len(badRequest.Fields) > 0 {
+			problem := newProblem(http.StatusBadRequest, "schema_violation", badRequest.Message)
+			problem.Errors = withPointers(badRequest.Fields)
+			return problem
+		}
+		return newProblem(http.StatusBadRequest, "bad_request", badRequest.Error())
+	case errors.As(err, &unauthorized):
+		return newProblem(http.StatusUnauthorized, "unauthorized", unauthorized.Error())
+	case errors.As(err, &forbidden):
+		problem := newProblem(http.StatusForbidden, "forbidden", forbidden.Error())
+		problem.Details = map[string]any{"permission": forbidden.Permission}
+		return problem
+	case errors.As(err, &precondition):
+		return newProblem(http.StatusPreconditionFailed, "precondition_failed", precondition.Error())
+	case errors.As(err, &mediaType):
+		return newProblem(http.StatusUnsupportedMediaType, "unsupported_media_type", mediaType.Error())
+	case errors.As(err, &notAcceptable):
+		return newProblem(http.StatusNotAcceptable, "not_acceptable", notAcceptable.Error())
+	}
+
+	return newProblem(http.StatusInternalServerError, "internal_error", "")
+}
+
+// HTTPStatus returns the status code an error is reported with.
+func HTTPStatus(err error) int {
+	return ToProblem(err).Status
+}
+
+// WriteHTTP writes err as an application/problem+json response.
+func WriteHTTP(w http.ResponseWriter, err error) {
+	problem := ToProblem(err)
+
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Println("Error marshaling problem: ", marshalErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	w.Write(body)
+}
+
+func newProblem(status int, code string, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+func withPointers(fields []FieldError) []FieldError {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	withPointer := make([]FieldError, len(fields))
+	for i, field := range fields {
+		if field.Pointer == "" {
+			field.Pointer = FieldPointer(field.Field)
+		}
+		withPointer[i] = field
+	}
+	return withPointer
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// ways a principal can be authenticated
+const (
+	MethodJWT    = "jwt"
+	MethodAPIKey = "api_key"
+)
+
+// Principal is who a request was authenticated as.
+type Principal struct {
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+	Method  string   `json:"method"`
+}
+
+// HasRole reports whether the principal was granted the role.
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+type principalKey struct{}
+
+// WithPrincipal marks ctx as coming from the principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of ctx, false when the request was not
+// authenticated, e.g. when authentication is turned off.
+func FromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Authenticator checks one kind of credentials. It returns nil and no error
+// when the request carries none of its kind, so the next one can try, and
+// an unauthorized error when they are present but wrong.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// signing algorithms accepted in tokens, "none" never is
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+// the smallest keys accepted, shorter ones are refused when loading
+const (
+	minHMACKeyBytes = 32
+	minRSAKeyBits   = 2048
+)
+
+// clockSkew is how far exp and nbf may be off between the issuer and us.
+const clockSkew = time.Minute
+
+// KeySet holds the keys tokens may be signed with. It is read from a JSON
+// Web Key Set (RFC 7517) like
+//
+//	{"keys": [
+//	  {"kty": "oct", "kid": "local", "alg": "HS256", "k": "<base64url secret>"},
+//	  {"kty": "RSA", "kid": "sso", "alg": "RS256", "n": "<base64url>", "e": "AQAB"}
+//	]}
+type KeySet struct {
+	keys []jwk
+}
+
+type jwk struct {
+	kid    string
+	alg    string
+	secret []byte
+	public *rsa.PublicKey
+}
+
+type jwkFile struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+		K   string `json:"k"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// LoadKeySet reads the key set from a json file.
+func LoadKeySet(path string) (*KeySet, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseKeySet(file)
+}
+
+func ParseKeySet(r io.Reader) (*KeySet, error) {
+	var body jwkFile
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, fmt.Errorf("reading the key set: %w", err)
+	}
+
+	set := &KeySet{}
+	for i, key := range body.Keys {
+		name := key.Kid
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+
+		switch key.Kty {
+		case "oct":
+			if key.Alg != "" && key.Alg != AlgHS256 {
+				return nil, fmt.Errorf("key set: key %s is oct but has alg %s", name, key.Alg)
+			}
+			secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.K, "="))
+			if err != nil {
+				return nil, fmt.Errorf("key set: key %s: %w", name, err)
+			}
+			if len(secret) < minHMACKeyBytes {
+				return nil, fmt.Errorf("key set: key %s is shorter than %d bytes", name, minHMACKeyBytes)
+			}
+			set.keys = append(set.keys, jwk{kid: key.Kid, alg: AlgHS256, secret: secret})
+
+		case "RSA":
+			if key.Alg != "" && key.Alg != AlgRS256 {
+				return nil, fmt.Errorf("key set: key %s is RSA but has alg %s", name, key.Alg)
+			}
+			n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.N, "="))
+			if err != nil {
+				return nil, fmt.Errorf("key set: key %s: %w", name, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(key.E, "="))
+			if err != nil {
+				return nil, fmt.Errorf("key set: key %s: %w", name, err)
+			}
+			public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+			if public.N.BitLen() < minRSAKeyBits || public.E < 3 {
+				return nil, fmt.Errorf("key set: key %s is not an RSA key of at least %d bits", name, minRSAKeyBits)
+			}
+			set.keys = append(set.keys, jwk{kid: key.Kid, alg: AlgRS256, public: public})
+
+		default:
+			return nil, fmt.Errorf("key set: key %s has unsupported kty %q", name, key.Kty)
+		}
+	}
+
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("key set has no keys")
+	}
+	return set, nil
+}
+
+// JWTAuthenticator accepts "Authorization: Bearer <jwt>". Tokens need a sub
+// and an exp, and when Issuer or Audience are set an iss and aud matching
+// them. The roles claim, a list of strings, becomes the principal's roles.
+type JWTAuthenticator struct {
+	Keys     *KeySet
+	Issuer   string
+	Audience string
+
+	// now is time.Now, swapped out to check expired tokens
+	now func() time.Time
+}
+
+func NewJWTAuthenticator(keys *KeySet, issuer string, audience string) *JWTAuthenticator {
+	return &JWTAuthenticator{Keys: keys, Issuer: issuer, Audience: audience, now: time.Now}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *json.Number    `json:"exp"`
+	NotBefore *json.Number    `json:"nbf"`
+	Roles     []string        `json:"roles"`
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, nil
+	}
+
+	claims, err := a.verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, err
+	}
+
+	roles := claims.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return &Principal{Subject: claims.Subject, Roles: roles, Method: MethodJWT}, nil
+}
+
+// verify checks the signature and then the claims of a compact jwt.
+func (a *JWTAuthenticator) verify(token string) (jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwtClaims{}, invalidToken("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return jwtClaims{}, invalidToken("malformed token header")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwtClaims{}, invalidToken("malformed token signature")
+	}
+
+	// the key decides the algorithm, a token cannot pick a weaker one
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range a.Keys.keys {
+		if key.alg != header.Alg || (header.Kid != "" && key.kid != header.Kid) {
+			continue
+		}
+		if key.verify(signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return jwtClaims{}, invalidToken("token signature is invalid")
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return jwtClaims{}, invalidToken("malformed token claims")
+	}
+	return claims, a.checkClaims(claims)
+}
+
+func (a *JWTAuthenticator) checkClaims(claims jwtClaims) error {
+	now := a.now()
+
+	if claims.Subject == "" {
+		return invalidToken("token has no subject")
+	}
+	if claims.ExpiresAt == nil {
+		return invalidToken("token has no expiry")
+	}
+	expiresAt, err := claims.ExpiresAt.Int64()
+	if err != nil {
+		return invalidToken("token expiry is not a number")
+	}
+	if now.After(time.Unix(expiresAt, 0).Add(clockSkew)) {
+		return invalidToken("token has expired")
+	}
+	if claims.NotBefore != nil {
+		notBefore, err := claims.NotBefore.Int64()
+		if err != nil {
+			return invalidToken("token nbf is not a number")
+		}
+		if now.Add(clockSkew).Before(time.Unix(notBefore, 0)) {
+			return invalidToken("token is not valid yet")
+		}
+	}
+
+	if a.Issuer != "" && claims.Issuer != a.Issuer {
+		return invalidToken("token issuer is not accepted")
+	}
+	if a.Audience != "" && !hasAudience(claims.Audience, a.Audience) {
+		return invalidToken("token audience is not accepted")
+	}
+	return nil
+}
+
+// hasAudience reads aud, which is either a string or a list of strings.
+func hasAudience(raw json.RawMessage, audience string) bool {
+	var one string
+	if json.Unmarshal(raw, &one) == nil {
+		return one == audience
+	}
+	var many []string
+	if json.Unmarshal(raw, &many) == nil {
+		for _, candidate := range many {
+			if candidate == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (k jwk) verify(signed []byte, signature []byte) bool {
+	switch k.alg {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case AlgRS256:
+		digest := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+func invalidToken(reason string) error {
+	return apperrors.NewUnauthorized(reason)
+}
@@ -0,0 +1,334 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+	testNow    = time.Unix(1700000000, 0)
+)
+
+// testKeys is a key set holding an HMAC key with kid "local" and an RSA
+// key with kid "sso", and the RSA private key to sign with.
+func testKeys(t *testing.T) (*KeySet, *rsa.PrivateKey) {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := ParseKeySet(strings.NewReader(`{"keys":[
+		{"kty":"oct","kid":"local","alg":"HS256","k":"` + b64(hmacSecret) + `"},
+		{"kty":"RSA","kid":"sso","alg":"RS256","n":"` + b64(private.N.Bytes()) + `","e":"` + b64(big.NewInt(int64(private.E)).Bytes()) + `"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys, private
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// token builds a compact jwt, sign returning the signature of its first
+// two segments.
+func token(t *testing.T, header map[string]any, claims map[string]any, sign func(signed []byte) []byte) string {
+	t.Helper()
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := b64(headerJSON) + "." + b64(claimsJSON)
+	return signed + "." + b64(sign([]byte(signed)))
+}
+
+func hs256(secret []byte) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(t *testing.T, private *rsa.PrivateKey) func([]byte) []byte {
+	return func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	keys, private := testKeys(t)
+	other, err := rsa.GenerateKey(rand.Reader, minRSAKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// claims returns valid claims with the changes applied, a nil value
+	// removing the claim
+	claims := func(changes map[string]any) map[string]any {
+		c := map[string]any{
+			"sub":   "alice",
+			"iss":   "https://sso.example.com",
+			"aud":   "cars",
+			"exp":   testNow.Add(time.Hour).Unix(),
+			"roles": []string{RoleEditor},
+		}
+		for name, value := range changes {
+			if value == nil {
+				delete(c, name)
+				continue
+			}
+			c[name] = value
+		}
+		return c
+	}
+	local := map[string]any{"alg": AlgHS256, "kid": "local"}
+	sso := map[string]any{"alg": AlgRS256, "kid": "sso"}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "HS256",
+			token: token(t, local, claims(nil), hs256(hmacSecret)),
+		},
+		{
+			name:  "RS256",
+			token: token(t, sso, claims(nil), rs256(t, private)),
+		},
+		{
+			name:  "audience in a list",
+			token: token(t, local, claims(map[string]any{"aud": []string{"billing", "cars"}}), hs256(hmacSecret)),
+		},
+		{
+			name:  "no kid, signed with a known key",
+			token: token(t, map[string]any{"alg": AlgRS256}, claims(nil), rs256(t, private)),
+		},
+		{
+			name:    "bad HMAC signature",
+			token:   token(t, local, claims(nil), hs256([]byte("another secret of thirty-two bytes"))),
+			wantErr: true,
+		},
+		{
+			name:    "bad RSA signature",
+			token:   token(t, sso, claims(nil), rs256(t, other)),
+			wantErr: true,
+		},
+		{
+			name: "claims changed after signing",
+			token: func() string {
+				signed := token(t, local, claims(nil), hs256(hmacSecret))
+				parts := strings.Split(signed, ".")
+				forged, _ := json.Marshal(claims(map[string]any{"roles": []string{RoleAdmin}}))
+				return parts[0] + "." + b64(forged) + "." + parts[2]
+			}(),
+			wantErr: true,
+		},
+		{
+			name:    "HS256 signed with the RSA public key",
+			token:   token(t, map[string]any{"alg": AlgHS256, "kid": "sso"}, claims(nil), hs256(private.N.Bytes())),
+			wantErr: true,
+		},
+		{
+			name:    "HS256 signed with the RSA public key, without a kid",
+			token:   token(t, map[string]any{"alg": AlgHS256}, claims(nil), hs256(private.N.Bytes())),
+			wantErr: true,
+		},
+		{
+			name:    "alg none",
+			token:   token(t, map[string]any{"alg": "none", "kid": "local"}, claims(nil), func([]byte) []byte { return nil }),
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   token(t, map[string]any{"alg": AlgHS256, "kid": "elsewhere"}, claims(nil), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "kid of another key",
+			token:   token(t, map[string]any{"alg": AlgRS256, "kid": "local"}, claims(nil), rs256(t, private)),
+			wantErr: true,
+		},
+		{
+			name:    "no kid, signed with an unknown key",
+			token:   token(t, map[string]any{"alg": AlgRS256}, claims(nil), rs256(t, other)),
+			wantErr: true,
+		},
+		{
+			name:  "expired within the clock skew",
+			token: token(t, local, claims(map[string]any{"exp": testNow.Add(-30 * time.Second).Unix()}), hs256(hmacSecret)),
+		},
+		{
+			name:    "expired beyond the clock skew",
+			token:   token(t, local, claims(map[string]any{"exp": testNow.Add(-2 * time.Minute).Unix()}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "no expiry",
+			token:   token(t, local, claims(map[string]any{"exp": nil}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:  "not valid yet within the clock skew",
+			token: token(t, local, claims(map[string]any{"nbf": testNow.Add(30 * time.Second).Unix()}), hs256(hmacSecret)),
+		},
+		{
+			name:    "not valid yet beyond the clock skew",
+			token:   token(t, local, claims(map[string]any{"nbf": testNow.Add(2 * time.Minute).Unix()}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			token:   token(t, local, claims(map[string]any{"iss": "https://evil.example.com"}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "no issuer",
+			token:   token(t, local, claims(map[string]any{"iss": nil}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			token:   token(t, local, claims(map[string]any{"aud": "billing"}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "wrong audiences in a list",
+			token:   token(t, local, claims(map[string]any{"aud": []string{"billing", "fleet"}}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "no subject",
+			token:   token(t, local, claims(map[string]any{"sub": nil}), hs256(hmacSecret)),
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			token:   "not.a-jwt",
+			wantErr: true,
+		},
+	}
+
+	authenticator := NewJWTAuthenticator(keys, "https://sso.example.com", "cars")
+	authenticator.now = func() time.Time { return testNow }
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/cars", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+
+			principal, err := authenticator.Authenticate(r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Authenticate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if principal == nil || principal.Subject != "alice" || principal.Method != MethodJWT {
+				t.Fatalf("Authenticate() principal = %+v, want alice by jwt", principal)
+			}
+			if len(principal.Roles) != 1 || principal.Roles[0] != RoleEditor {
+				t.Errorf("Authenticate() roles = %v, want [%s]", principal.Roles, RoleEditor)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorWithoutBearer(t *testing.T) {
+	keys, _ := testKeys(t)
+	r := httptest.NewRequest("GET", "/api/v1/cars", nil)
+	r.Header.Set("Authorization", "ApiKey abc")
+
+	principal, err := NewJWTAuthenticator(keys, "", "").Authenticate(r)
+	if principal != nil || err != nil {
+		t.Fatalf("Authenticate() = %+v, %v, want it to leave the request to the other authenticators", principal, err)
+	}
+}
+
+func TestParseKeySet(t *testing.T) {
+	small, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallRSA := `"n":"` + b64(small.N.Bytes()) + `","e":"AQAB"`
+
+	tests := []struct {
+		name    string
+		keys    string
+		wantErr bool
+	}{
+		{
+			name: "HMAC key of 32 bytes",
+			keys: `{"keys":[{"kty":"oct","k":"` + b64(hmacSecret) + `"}]}`,
+		},
+		{
+			name:    "HMAC key of 31 bytes",
+			keys:    `{"keys":[{"kty":"oct","k":"` + b64(hmacSecret[:31]) + `"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "empty HMAC key",
+			keys:    `{"keys":[{"kty":"oct","k":""}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "RSA key of 1024 bits",
+			keys:    `{"keys":[{"kty":"RSA",` + smallRSA + `}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "oct key with an RSA alg",
+			keys:    `{"keys":[{"kty":"oct","alg":"RS256","k":"` + b64(hmacSecret) + `"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "oct key with alg none",
+			keys:    `{"keys":[{"kty":"oct","alg":"none","k":"` + b64(hmacSecret) + `"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported kty",
+			keys:    `{"keys":[{"kty":"EC","crv":"P-256"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "no keys",
+			keys:    `{"keys":[]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKeySet(strings.NewReader(tt.keys))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeySet() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
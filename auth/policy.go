@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// Permission names one kind of operation on the catalog.
+type Permission string
+
+const (
+	PermCarsRead      Permission = "cars:read"
+	PermCarsWrite     Permission = "cars:write"
+	PermCarsDelete    Permission = "cars:delete"
+	PermEnginesRead   Permission = "engines:read"
+	PermEnginesWrite  Permission = "engines:write"
+	PermEnginesDelete Permission = "engines:delete"
+
+	// reading soft deleted records, through include_deleted
+	PermReadDeleted Permission = "deleted:read"
+
+	// hard deleting old tombstones through POST /admin/purge
+	PermPurge Permission = "admin:purge"
+)
+
+// the roles principals can be given, each one grants what the one before
+// it does and more
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// Roles lists the known roles, from the least to the most trusted.
+var Roles = []string{RoleViewer, RoleEditor, RoleAdmin}
+
+// Policy decides whether the principal of ctx may do an operation. The
+// services ask it first thing in every method, so the rules hold whatever
+// the transport.
+type Policy interface {
+	Authorize(ctx context.Context, permission Permission) error
+}
+
+// RolePolicy grants permissions through the roles of the principal.
+//
+// A context without a principal belongs to the process itself, e.g. the
+// command line, the purge job or an api running with authentication turned
+// off, and is allowed everything. Requests through the api with
+// authentication on always carry a principal.
+type RolePolicy struct {
+	grants map[string][]Permission
+}
+
+// DefaultPolicy lets viewers read, editors also create and update, and
+// admins also delete, restore, see deleted records and purge.
+func DefaultPolicy() *RolePolicy {
+	viewer := []Permission{PermCarsRead, PermEnginesRead}
+	editor := append(slices.Clone(viewer), PermCarsWrite, PermEnginesWrite)
+	admin := append(slices.Clone(editor), PermCarsDelete, PermEnginesDelete, PermReadDeleted, PermPurge)
+
+	return &RolePolicy{grants: map[string][]Permission{
+		RoleViewer: viewer,
+		RoleEditor: editor,
+		RoleAdmin:  admin,
+	}}
+}
+
+func (p *RolePolicy) Authorize(ctx context.Context, permission Permission) error {
+	principal, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, role := range principal.Roles {
+		if slices.Contains(p.grants[role], permission) {
+			return nil
+		}
+	}
+	return apperrors.NewForbidden(string(permission))
+}
+
+// ValidateRoles checks that every role is one the policy knows, so a typo
+// in a key's roles is caught when it is created rather than when it is used.
+func ValidateRoles(roles []string) error {
+	for _, role := range roles {
+		if !slices.Contains(Roles, role) {
+			return fmt.Errorf("unknown role %q, use one of viewer, editor, admin", role)
+		}
+	}
+	return nil
+}
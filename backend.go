@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/TheMikeKaisen/CarManagement/driver"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	apikeyStore "github.com/TheMikeKaisen/CarManagement/store/apikey"
+	carStore "github.com/TheMikeKaisen/CarManagement/store/car"
+	engineStore "github.com/TheMikeKaisen/CarManagement/store/engine"
+	"github.com/TheMikeKaisen/CarManagement/store/memory"
+)
+
+// backend bundles the stores chosen through STORE_BACKEND. db is nil for
+// backends that are not sql based.
+type backend struct {
+	db      *store.DB
+	cars    store.CarStoreInterface
+	engines store.EngineStoreInterface
+	apiKeys store.APIKeyStoreInterface
+}
+
+func openBackend(ctx context.Context, cfg config) (backend, error) {
+	switch cfg.storeBackend {
+	case "postgres":
+		db, err := driver.ConnectPostgres(ctx, cfg.db)
+		if err != nil {
+			return backend{}, err
+		}
+		return newSQLBackend(store.NewDB(db, store.Postgres)), nil
+
+	case "sqlite":
+		db, err := driver.ConnectSQLite(ctx, cfg.sqlitePath)
+		if err != nil {
+			return backend{}, err
+		}
+		return newSQLBackend(store.NewDB(db, store.SQLite)), nil
+
+	case "memory":
+		// handy for local demos, everything is lost on restart
+		mem := memory.New()
+		return backend{cars: mem, engines: mem, apiKeys: mem}, nil
+	}
+
+	return backend{}, fmt.Errorf("unknown store backend %q", cfg.storeBackend)
+}
+
+func newSQLBackend(db *store.DB) backend {
+	return backend{db: db, cars: carStore.New(db), engines: engineStore.New(db), apiKeys: apikeyStore.New(db)}
+}
+
+func (b backend) Close() error {
+	if b.db == nil {
+		return nil
+	}
+	return b.db.Close()
+}
+
+// sqlDB returns the raw connection pool, nil for non sql backends.
+func (b backend) sqlDB() *sql.DB {
+	if b.db == nil {
+		return nil
+	}
+	return b.db.DB
+}
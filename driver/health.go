@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Health is a snapshot of the database status and its connection pool.
+type Health struct {
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	Latency      string `json:"latency"`
+	OpenConns    int    `json:"open_connections"`
+	InUse        int    `json:"in_use"`
+	Idle         int    `json:"idle"`
+	WaitCount    int64  `json:"wait_count"`
+	MaxOpenConns int    `json:"max_open_connections"`
+}
+
+// IsHealthy reports whether the last ping succeeded.
+func (h Health) IsHealthy() bool {
+	return h.Status == "up"
+}
+
+// CheckHealth pings the database and reports the pool statistics.
+func CheckHealth(ctx context.Context, db *sql.DB) Health {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := db.PingContext(ctx)
+	stats := db.Stats()
+
+	health := Health{
+		Status:       "up",
+		Latency:      time.Since(start).String(),
+		OpenConns:    stats.OpenConnections,
+		InUse:        stats.InUse,
+		Idle:         stats.Idle,
+		WaitCount:    stats.WaitCount,
+		MaxOpenConns: stats.MaxOpenConnections,
+	}
+	if err != nil {
+		health.Status = "down"
+		health.Error = err.Error()
+	}
+
+	return health
+}
@@ -1,2 +1,181 @@
 package driver
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// the startup retry waits used when none, or one that is not positive, is
+// configured
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// Config holds everything needed to reach the postgres database and
+// size its connection pool.
+type Config struct {
+	// URL, when set, is used as-is instead of the individual fields below.
+	URL string
+
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+
+	// pool settings
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// startup retry settings, used while the database container comes up
+	ConnectAttempts int
+	InitialBackoff  time.Duration
+	MaxBackoff      time.Duration
+}
+
+// ConfigFromEnv reads the database settings from the environment,
+// falling back to the values used by docker-compose.
+func ConfigFromEnv() Config {
+	return Config{
+		URL:      os.Getenv("DATABASE_URL"),
+		Host:     getEnv("DB_HOST", "localhost"),
+		Port:     getEnv("DB_PORT", "5432"),
+		User:     getEnv("DB_USER", "postgres"),
+		Password: getEnv("DB_PASSWORD", "postgres"),
+		Name:     getEnv("DB_NAME", "car_management"),
+		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+		MaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		MaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 25),
+		ConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		ConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+
+		ConnectAttempts: getEnvInt("DB_CONNECT_ATTEMPTS", 10),
+		InitialBackoff:  getEnvPositiveDuration("DB_CONNECT_BACKOFF", defaultInitialBackoff),
+		MaxBackoff:      getEnvPositiveDuration("DB_CONNECT_MAX_BACKOFF", defaultMaxBackoff),
+	}
+}
+
+// DSN builds the connection string understood by lib/pq.
+func (c Config) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   c.Host + ":" + c.Port,
+		Path:   "/" + c.Name,
+	}
+	query := dsn.Query()
+	query.Set("sslmode", c.SSLMode)
+	dsn.RawQuery = query.Encode()
+
+	return dsn.String()
+}
+
+// ConnectPostgres opens the database, applies the pool settings and waits
+// until the server answers a ping, retrying with exponential backoff.
+func ConnectPostgres(ctx context.Context, cfg Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	if err := waitForDB(ctx, db, cfg); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to reach postgres: %w", err)
+	}
+
+	return db, nil
+}
+
+func waitForDB(ctx context.Context, db *sql.DB, cfg Config) error {
+	attempts := cfg.ConnectAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	// without a wait the retries would all be spent at once
+	backoff := cfg.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = db.PingContext(ctx); err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.Printf("Database not ready (attempt %d/%d): %v, retrying in %s", attempt, attempts, err, backoff)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		// double the wait every time, up to the configured maximum
+		backoff *= 2
+		if cfg.MaxBackoff > 0 && backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+
+	return err
+}
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvPositiveDuration is getEnvDuration for settings where zero or a
+// negative duration makes no sense, those fall back as well.
+func getEnvPositiveDuration(key string, fallback time.Duration) time.Duration {
+	value := getEnvDuration(key, fallback)
+	if value <= 0 {
+		log.Printf("Ignoring %s=%s, it has to be positive, using %s", key, os.Getenv(key), fallback)
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,43 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteDSN builds a modernc.org/sqlite connection string for the file at
+// path, turning on foreign keys and WAL so readers don't block the writer.
+// Times are written as "2006-01-02 15:04:05.999999999-07:00" instead of
+// time.Time.String, whose monotonic clock suffix breaks comparisons; the
+// store binds them in UTC so the text also sorts in time order.
+func SQLiteDSN(path string) string {
+	query := url.Values{}
+	query.Add("_pragma", "foreign_keys(1)")
+	query.Add("_pragma", "busy_timeout(5000)")
+	query.Add("_pragma", "journal_mode(WAL)")
+	query.Add("_time_format", "sqlite")
+
+	return "file:" + path + "?" + query.Encode()
+}
+
+// ConnectSQLite opens (and creates if missing) the sqlite database file.
+func ConnectSQLite(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", SQLiteDSN(path))
+	if err != nil {
+		return nil, err
+	}
+
+	// sqlite allows a single writer, a small pool avoids "database is locked"
+	db.SetMaxOpenConns(4)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to open sqlite database %s: %w", path, err)
+	}
+
+	return db, nil
+}
@@ -0,0 +1,234 @@
+// Package events tells the clients of GET /events about the changes to the
+// catalog. The services publish an event once a write is committed, the
+// broker keeps the latest ones in a bounded buffer so a client that lost
+// its connection can resume from the last event it saw.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// the types of the events, the data of each is the record as the REST api
+// answers with it after the write
+const (
+	CarCreated  = "car.created"
+	CarUpdated  = "car.updated"
+	CarDeleted  = "car.deleted"
+	CarRestored = "car.restored"
+
+	EngineCreated  = "engine.created"
+	EngineUpdated  = "engine.updated"
+	EngineDeleted  = "engine.deleted"
+	EngineRestored = "engine.restored"
+
+	// Reset tells a resuming client that it missed events
+	Reset = "reset"
+)
+
+// subscriberBuffer is how many events a subscriber may fall behind before
+// it is dropped. A dropped client reconnects and resumes from the buffer.
+const subscriberBuffer = 64
+
+// Event is one change of the catalog.
+type Event struct {
+	ID   uint64
+	Type string
+	Data json.RawMessage
+	Time time.Time
+}
+
+// DeletedEngine is the data of engine.deleted: the engine and what became
+// of the cars that used it. The cars detached or deleted with the engine
+// get no events of their own.
+type DeletedEngine struct {
+	models.Engine
+	Cascade models.CascadeMode `json:"cascade,omitempty"`
+}
+
+// Broker hands the published events to the subscribers, keeping the last
+// ones in a ring buffer to replay. It lives in the process, the clients of
+// another instance of the api do not see its events.
+type Broker struct {
+	policy auth.Policy
+
+	mu     sync.Mutex
+	buffer []Event
+	// head is where the next event goes, count how many the buffer holds
+	head   int
+	count  int
+	lastID uint64
+	closed bool
+
+	subscribers map[*Subscription]struct{}
+}
+
+// NewBroker keeps the last size events. Event ids start at the time the
+// broker is created, in microseconds, so they keep growing across restarts
+// and an id from before a restart is never mistaken for a later event.
+func NewBroker(size int, policy auth.Policy) *Broker {
+	return &Broker{
+		policy:      policy,
+		buffer:      make([]Event, size),
+		lastID:      uint64(time.Now().UnixMicro()),
+		subscribers: map[*Subscription]struct{}{},
+	}
+}
+
+// Publish sends an event to the subscribers allowed to see it. The
+// services call it once a write is committed, never for one rolled back.
+func (b *Broker) Publish(eventType string, data any) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		log.Println("Error marshaling the event ", eventType, ": ", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, Data: body, Time: time.Now()}
+	if len(b.buffer) > 0 {
+		b.buffer[b.head] = event
+		b.head = (b.head + 1) % len(b.buffer)
+		if b.count < len(b.buffer) {
+			b.count++
+		}
+	}
+
+	for subscriber := range b.subscribers {
+		if !subscriber.allows(eventType) {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+			// too slow to keep up, it resumes from the buffer
+			log.Println("Dropping a subscriber that is ", subscriberBuffer, " events behind")
+			b.remove(subscriber)
+		}
+	}
+}
+
+// Subscription is a client of the stream. Replay holds the buffered events
+// after the one it resumes from, Events the later ones. Reset is set when
+// it resumes from an event no longer buffered: events were missed, so
+// nothing is replayed and the client has to read the catalog again.
+type Subscription struct {
+	Replay []Event
+	Reset  bool
+	// LastID is the id of the newest event when it subscribed
+	LastID uint64
+
+	events  chan Event
+	cars    bool
+	engines bool
+	broker  *Broker
+}
+
+// Events is closed when the subscriber falls behind or the broker closes.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Close stops the events of the subscription.
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+func (s *Subscription) allows(eventType string) bool {
+	switch {
+	case strings.HasPrefix(eventType, "car."):
+		return s.cars
+	case strings.HasPrefix(eventType, "engine."):
+		return s.engines
+	}
+	return false
+}
+
+// Subscribe streams the events the principal of ctx may read: car events
+// with cars:read and engine events with engines:read. lastEventID is the
+// id of the last event the client saw, empty for a new client.
+func (b *Broker) Subscribe(ctx context.Context, lastEventID string) (*Subscription, error) {
+	carsErr := b.policy.Authorize(ctx, auth.PermCarsRead)
+	enginesErr := b.policy.Authorize(ctx, auth.PermEnginesRead)
+	if carsErr != nil && enginesErr != nil {
+		return nil, carsErr
+	}
+
+	subscription := &Subscription{
+		events:  make(chan Event, subscriberBuffer),
+		cars:    carsErr == nil,
+		engines: enginesErr == nil,
+		broker:  b,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	subscription.LastID = b.lastID
+	if lastEventID != "" {
+		subscription.Replay, subscription.Reset = b.since(lastEventID, subscription)
+	}
+
+	if b.closed {
+		close(subscription.events)
+		return subscription, nil
+	}
+	b.subscribers[subscription] = struct{}{}
+	return subscription, nil
+}
+
+// since returns the buffered events after lastEventID, or true when events
+// after it are no longer buffered or it is not an id of this stream.
+func (b *Broker) since(lastEventID string, subscription *Subscription) ([]Event, bool) {
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || lastID > b.lastID {
+		return nil, true
+	}
+
+	oldest := b.lastID - uint64(b.count) + 1
+	if lastID+1 < oldest {
+		return nil, true
+	}
+
+	var replay []Event
+	for i := 0; i < b.count; i++ {
+		event := b.buffer[(b.head-b.count+i+len(b.buffer))%len(b.buffer)]
+		if event.ID > lastID && subscription.allows(event.Type) {
+			replay = append(replay, event)
+		}
+	}
+	return replay, false
+}
+
+// Close ends every subscription, for the server to shut down.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for subscriber := range b.subscribers {
+		b.remove(subscriber)
+	}
+}
+
+// remove unregisters a subscriber, with b.mu held.
+func (b *Broker) remove(subscriber *Subscription) {
+	if _, ok := b.subscribers[subscriber]; !ok {
+		return
+	}
+	delete(b.subscribers, subscriber)
+	close(subscriber.events)
+}
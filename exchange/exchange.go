@@ -0,0 +1,136 @@
+package exchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// Rates is an exchange rate table loaded from a local file like
+//
+//	{"base": "USD", "rates": {"EUR": "0.92", "JPY": "151.3"}}
+//
+// where every rate is the amount of that currency one unit of the base
+// buys. Rates are kept as exact fractions, so the only rounding is to the
+// minor unit of the target currency.
+type Rates struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+type ratesFile struct {
+	Base  string                 `json:"base"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// Load reads the rate table from a json file.
+func Load(path string) (*Rates, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+func Parse(r io.Reader) (*Rates, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var body ratesFile
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("reading exchange rates: %w", err)
+	}
+	if !models.IsCurrency(body.Base) {
+		return nil, fmt.Errorf("exchange rates: unknown base currency %q", body.Base)
+	}
+
+	rates := &Rates{base: body.Base, rates: map[string]*big.Rat{body.Base: big.NewRat(1, 1)}}
+	for code, number := range body.Rates {
+		if !models.IsCurrency(code) {
+			return nil, fmt.Errorf("exchange rates: unknown currency %q", code)
+		}
+		rate, ok := new(big.Rat).SetString(number.String())
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("exchange rates: invalid rate %s for %s", number, code)
+		}
+		rates.rates[code] = rate
+	}
+
+	return rates, nil
+}
+
+// Convert returns the amount in the target currency, rounded half away
+// from zero to its minor unit. Without a table only amounts already in the
+// target currency can be converted.
+func (r *Rates) Convert(money models.Money, to string) (models.Money, error) {
+	if money.Currency == to {
+		return money, nil
+	}
+	if r == nil {
+		return models.Money{}, apperrors.NewBadRequest("currency conversion is not configured", nil)
+	}
+
+	from, ok := r.rates[money.Currency]
+	if !ok {
+		return models.Money{}, noRate(money.Currency)
+	}
+	target, ok := r.rates[to]
+	if !ok {
+		return models.Money{}, noRate(to)
+	}
+
+	// minor units of from -> units of the base -> minor units of to
+	amount := new(big.Rat).SetInt64(money.AmountMinor)
+	amount.Quo(amount, pow10(models.MinorDigits(money.Currency)))
+	amount.Quo(amount, from)
+	amount.Mul(amount, target)
+	amount.Mul(amount, pow10(models.MinorDigits(to)))
+
+	return models.Money{AmountMinor: round(amount), Currency: to}, nil
+}
+
+// CheckTarget reports whether amounts can be converted to the currency at
+// all, so a long conversion fails before it starts rather than midway.
+// Amounts in currencies the table lacks still fail in Convert.
+func (r *Rates) CheckTarget(to string) error {
+	if r == nil {
+		return apperrors.NewBadRequest("currency conversion is not configured", nil)
+	}
+	if _, ok := r.rates[to]; !ok {
+		return noRate(to)
+	}
+	return nil
+}
+
+func noRate(currency string) error {
+	return apperrors.NewFieldValidation([]apperrors.FieldError{
+		{Field: "currency", Code: "no_exchange_rate", Message: "no exchange rate for " + currency},
+	})
+}
+
+func pow10(exponent int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil))
+}
+
+// round rounds a fraction half away from zero.
+func round(amount *big.Rat) int64 {
+	num := new(big.Int).Abs(amount.Num())
+	denom := amount.Denom()
+
+	// (2*num + denom) / (2*denom) is num/denom rounded half up
+	twice := new(big.Int).Mul(num, big.NewInt(2))
+	twice.Add(twice, denom)
+	result := twice.Quo(twice, new(big.Int).Mul(denom, big.NewInt(2)))
+
+	if amount.Sign() < 0 {
+		result.Neg(result)
+	}
+	return result.Int64()
+}
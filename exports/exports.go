@@ -0,0 +1,217 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+// Format is the media type of an export.
+type Format string
+
+const (
+	FormatCSV    Format = "text/csv"
+	FormatNDJSON Format = "application/x-ndjson"
+	FormatXLSX   Format = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// formats maps the ?format= names to their media type, csv first as it is
+// the default.
+var formats = []struct {
+	name   string
+	format Format
+}{
+	{"csv", FormatCSV},
+	{"ndjson", FormatNDJSON},
+	{"xlsx", FormatXLSX},
+}
+
+// Produced lists the media types for error messages.
+var Produced = []string{string(FormatCSV), string(FormatNDJSON), string(FormatXLSX)}
+
+// Extension is the file extension of the format, without the dot.
+func (f Format) Extension() string {
+	for _, known := range formats {
+		if known.format == f {
+			return known.name
+		}
+	}
+	return ""
+}
+
+// Negotiate picks the export format from ?format=, which wins, or else the
+// Accept header. Without either the export is csv.
+func Negotiate(name string, accept string) (Format, error) {
+	if name != "" {
+		for _, known := range formats {
+			if known.name == name {
+				return known.format, nil
+			}
+		}
+		return "", apperrors.NewBadRequest("query parameter format must be csv, ndjson or xlsx", nil)
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, nil
+	}
+
+	// the accepted media range with the highest q value wins, ties go to
+	// the one listed first
+	var best Format
+	bestQuality := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+
+		format, ok := matchMediaRange(mediaType)
+		if ok && quality > bestQuality {
+			best, bestQuality = format, quality
+		}
+	}
+
+	if best == "" {
+		return "", apperrors.NewNotAcceptable(accept, Produced...)
+	}
+	return best, nil
+}
+
+func matchMediaRange(mediaType string) (Format, bool) {
+	switch mediaType {
+	case "*/*", "text/*":
+		return FormatCSV, true
+	case "application/ndjson", "application/jsonl":
+		return FormatNDJSON, true
+	}
+	for _, known := range formats {
+		if string(known.format) == mediaType {
+			return known.format, true
+		}
+	}
+	return "", false
+}
+
+// Writer writes cars one at a time in an export format. Close finishes
+// the file and has to be called even when no car was written.
+type Writer interface {
+	Write(car models.Car) error
+	Close() error
+}
+
+func NewWriter(format Format, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	}
+	return nil, apperrors.NewNotAcceptable(string(format), Produced...)
+}
+
+// Columns are the columns of the csv and xlsx exports. Prices are decimal
+// amounts in their currency, the converted ones are only filled when the
+// export asked for a currency.
+var Columns = []string{
+	"id", "name", "year", "brand", "fuel_type", "price", "currency",
+	"converted_price", "converted_currency",
+	"engine_id", "displacement", "no_of_cylinders", "car_range",
+	"created_at", "updated_at", "deleted_at",
+}
+
+// cell is one value of a row, numbers are kept apart so spreadsheets do
+// not read them as text.
+type cell struct {
+	text   string
+	number bool
+}
+
+func text(value string) cell {
+	return cell{text: value}
+}
+
+func number(value string) cell {
+	return cell{text: value, number: true}
+}
+
+// row flattens a car into the cells of Columns. Cars without an engine
+// leave the engine cells empty.
+func row(car models.Car) []cell {
+	cells := []cell{
+		text(car.ID.String()), text(car.Name), text(car.Year), text(car.Brand), text(car.FuelType),
+		number(car.Price.Decimal()), text(car.Price.Currency),
+		{}, {},
+		{}, {}, {}, {},
+		text(car.CreatedAt.UTC().Format(time.RFC3339Nano)), text(car.UpdatedAt.UTC().Format(time.RFC3339Nano)), {},
+	}
+
+	if car.ConvertedPrice != nil {
+		cells[7] = number(car.ConvertedPrice.Decimal())
+		cells[8] = text(car.ConvertedPrice.Currency)
+	}
+	if car.Engine.EngineId != uuid.Nil {
+		cells[9] = text(car.Engine.EngineId.String())
+		cells[10] = number(strconv.FormatInt(car.Engine.Displacement, 10))
+		cells[11] = number(strconv.FormatInt(car.Engine.NoOfCylinders, 10))
+		cells[12] = number(strconv.FormatInt(car.Engine.CarRange, 10))
+	}
+	if car.DeletedAt != nil {
+		cells[15] = text(car.DeletedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return cells
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(Columns); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (c *csvWriter) Write(car models.Car) error {
+	cells := row(car)
+	record := make([]string, len(cells))
+	for i, cell := range cells {
+		record[i] = cell.text
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// ndjsonWriter writes every car the way GET /cars/{id} returns it.
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(car models.Car) error {
+	return n.encoder.Encode(car)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package graph serves the cars and engines as a GraphQL api, next to the
+// REST and gRPC ones and backed by the same services. The schema is in
+// schema.graphql.
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/service"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSource string
+
+// maxDepth keeps queries from nesting selections without end
+const maxDepth = 10
+
+// request is a GraphQL request as it is posted, in json.
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Handler serves GraphQL requests posted to it. Every request gets its own
+// loaders, so the engines of the cars it lists are read in batches.
+type Handler struct {
+	schema  *graphql.Schema
+	engines service.EngineServiceInterface
+	maxBody int64
+}
+
+// NewHandler parses the schema against the resolvers, so a schema the
+// resolvers do not match fails at startup. Request bodies may be up to
+// maxBody bytes long.
+func NewHandler(cars service.CarServiceInterface, engines service.EngineServiceInterface, maxBody int64) *Handler {
+	resolver := &Resolver{cars: cars, engines: engines}
+	schema := graphql.MustParseSchema(schemaSource, resolver, graphql.UseStringDescriptions(), graphql.MaxDepth(maxDepth))
+	return &Handler{schema: schema, engines: engines, maxBody: maxBody}
+}
+
+// ServeHTTP answers 200 with the data and the errors of the fields that
+// failed, as GraphQL does. Only a body that is not a GraphQL request is
+// answered with a problem.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBody))
+	if err := decoder.Decode(&req); err != nil {
+		log.Println("Error decoding the GraphQL request: ", err)
+		apperrors.WriteHTTP(w, apperrors.NewBadRequest("the body must be a json GraphQL request", err))
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.engines)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	// the errors of the services are reported with the codes of the
+	// REST api, the errors of the query itself as they are
+	for _, queryErr := range response.Errors {
+		if queryErr.ResolverError == nil {
+			continue
+		}
+		log.Println("Error resolving ", queryErr.Path, ": ", queryErr.ResolverError)
+		queryErr.Message, queryErr.Extensions = apperrors.GraphQLError(queryErr.ResolverError)
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		log.Println("Error marshaling the GraphQL response: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/service"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader"
+)
+
+// batchWait is how long a loader collects the keys of a batch. The fields
+// of a list are resolved concurrently, so the engines of one page of cars
+// are asked for well within it.
+const batchWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+// loaders batch and cache the reads of one request. Soft deleted engines
+// are loaded apart, the same id is another engine to them.
+type loaders struct {
+	engines        *dataloader.Loader
+	deletedEngines *dataloader.Loader
+}
+
+func withLoaders(ctx context.Context, engines service.EngineServiceInterface) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		engines:        newEngineLoader(engines, false),
+		deletedEngines: newEngineLoader(engines, true),
+	})
+}
+
+func newEngineLoader(engines service.EngineServiceInterface, includeDeleted bool) *dataloader.Loader {
+	return dataloader.NewBatchedLoader(
+		engineBatch(engines, includeDeleted),
+		dataloader.WithWait(batchWait),
+		dataloader.WithBatchCapacity(models.MaxPageSize),
+	)
+}
+
+// loadEngine reads an engine through the loaders of the request, nil when
+// there is none.
+func loadEngine(ctx context.Context, id uuid.UUID, includeDeleted bool) (*models.Engine, error) {
+	l := ctx.Value(loadersKey{}).(*loaders)
+	loader := l.engines
+	if includeDeleted {
+		loader = l.deletedEngines
+	}
+
+	value, err := loader.Load(ctx, dataloader.StringKey(id.String()))()
+	if err != nil {
+		return nil, err
+	}
+	return value.(*models.Engine), nil
+}
+
+// engineBatch reads the engines of a batch with one GetEnginesByIds call
+// and hands them back in the order of the keys.
+func engineBatch(engines service.EngineServiceInterface, includeDeleted bool) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		if includeDeleted {
+			ctx = store.WithDeleted(ctx)
+		}
+
+		results := make([]*dataloader.Result, len(keys))
+		found, err := engines.GetEnginesByIds(ctx, keys.Keys())
+		if err != nil {
+			for i := range results {
+				results[i] = &dataloader.Result{Error: err}
+			}
+			return results
+		}
+
+		byId := make(map[string]*models.Engine, len(found))
+		for i := range found {
+			byId[found[i].EngineId.String()] = &found[i]
+		}
+		for i, key := range keys {
+			results[i] = &dataloader.Result{Data: byId[key.String()]}
+		}
+		return results
+	}
+}
@@ -0,0 +1,344 @@
+package graph
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/service"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver resolves the queries and mutations of the schema, the REST
+// handlers for GraphQL.
+type Resolver struct {
+	cars    service.CarServiceInterface
+	engines service.EngineServiceInterface
+}
+
+type carFilterInput struct {
+	Brand    *string
+	FuelType *string
+
+	MinYear       *int32
+	MaxYear       *int32
+	PriceCurrency *string
+	MinPrice      *Int64
+	MaxPrice      *Int64
+
+	MinDisplacement  *Int64
+	MaxDisplacement  *Int64
+	MinNoOfCylinders *Int64
+	MaxNoOfCylinders *Int64
+	MinCarRange      *Int64
+	MaxCarRange      *Int64
+}
+
+type engineFilterInput struct {
+	MinDisplacement  *Int64
+	MaxDisplacement  *Int64
+	MinNoOfCylinders *Int64
+	MaxNoOfCylinders *Int64
+	MinCarRange      *Int64
+	MaxCarRange      *Int64
+}
+
+type carInput struct {
+	Name     string
+	Year     string
+	Brand    string
+	FuelType string
+	Engine   carEngineInput
+	Price    moneyInput
+}
+
+type carEngineInput struct {
+	EngineId      graphql.ID
+	Displacement  Int64
+	NoOfCylinders Int64
+	CarRange      Int64
+}
+
+type engineInput struct {
+	Displacement  Int64
+	NoOfCylinders Int64
+	CarRange      Int64
+}
+
+type moneyInput struct {
+	AmountMinor Int64
+	Currency    string
+}
+
+func (r *Resolver) Car(ctx context.Context, args struct {
+	ID             graphql.ID
+	IncludeDeleted bool
+}) (*carResolver, error) {
+	ctx = withDeleted(ctx, args.IncludeDeleted)
+
+	car, err := r.cars.GetCarById(ctx, string(args.ID))
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &carResolver{car: *car, includeDeleted: args.IncludeDeleted}, nil
+}
+
+func (r *Resolver) Cars(ctx context.Context, args struct {
+	Filter         *carFilterInput
+	Sort           *string
+	Limit          *int32
+	Cursor         *string
+	Currency       *string
+	IncludeDeleted bool
+}) (*carPageResolver, error) {
+	ctx = withDeleted(ctx, args.IncludeDeleted)
+
+	filter := models.CarFilter{
+		Sort:     models.ParseSort(value(args.Sort)),
+		Limit:    int(value(args.Limit)),
+		Cursor:   value(args.Cursor),
+		Currency: value(args.Currency),
+	}
+	if f := args.Filter; f != nil {
+		// the price bounds are in the price currency unless it is unset
+		boundCurrency := value(f.PriceCurrency)
+		if boundCurrency == "" {
+			boundCurrency = models.DefaultCurrency
+		}
+
+		filter.Brand = value(f.Brand)
+		filter.FuelType = value(f.FuelType)
+		filter.MinYear = intOf(f.MinYear)
+		filter.MaxYear = intOf(f.MaxYear)
+		filter.PriceCurrency = value(f.PriceCurrency)
+		filter.MinPrice = moneyOf(f.MinPrice, boundCurrency)
+		filter.MaxPrice = moneyOf(f.MaxPrice, boundCurrency)
+		filter.MinDisplacement = int64Of(f.MinDisplacement)
+		filter.MaxDisplacement = int64Of(f.MaxDisplacement)
+		filter.MinNoOfCylinders = int64Of(f.MinNoOfCylinders)
+		filter.MaxNoOfCylinders = int64Of(f.MaxNoOfCylinders)
+		filter.MinCarRange = int64Of(f.MinCarRange)
+		filter.MaxCarRange = int64Of(f.MaxCarRange)
+	}
+
+	page, err := r.cars.ListCars(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &carPageResolver{page: page, includeDeleted: args.IncludeDeleted}, nil
+}
+
+func (r *Resolver) Engine(ctx context.Context, args struct {
+	ID             graphql.ID
+	IncludeDeleted bool
+}) (*engineResolver, error) {
+	ctx = withDeleted(ctx, args.IncludeDeleted)
+
+	engine, err := r.engines.GetEngineById(ctx, string(args.ID))
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &engineResolver{engine: engine}, nil
+}
+
+func (r *Resolver) Engines(ctx context.Context, args struct {
+	Filter          *engineFilterInput
+	Sort            *string
+	Limit           *int32
+	Cursor          *string
+	IncludeCarCount bool
+	IncludeDeleted  bool
+}) (*enginePageResolver, error) {
+	ctx = withDeleted(ctx, args.IncludeDeleted)
+
+	filter := models.EngineFilter{
+		IncludeCarCount: args.IncludeCarCount,
+
+		Sort:   models.ParseSort(value(args.Sort)),
+		Limit:  int(value(args.Limit)),
+		Cursor: value(args.Cursor),
+	}
+	if f := args.Filter; f != nil {
+		filter.MinDisplacement = int64Of(f.MinDisplacement)
+		filter.MaxDisplacement = int64Of(f.MaxDisplacement)
+		filter.MinNoOfCylinders = int64Of(f.MinNoOfCylinders)
+		filter.MaxNoOfCylinders = int64Of(f.MaxNoOfCylinders)
+		filter.MinCarRange = int64Of(f.MinCarRange)
+		filter.MaxCarRange = int64Of(f.MaxCarRange)
+	}
+
+	page, err := r.engines.ListEngines(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &enginePageResolver{page: page}, nil
+}
+
+func (r *Resolver) CreateCar(ctx context.Context, args struct{ Input carInput }) (*carResolver, error) {
+	carReq, err := carRequestOf(args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := r.cars.CreateCar(ctx, carReq)
+	if err != nil {
+		return nil, err
+	}
+	return &carResolver{car: *car}, nil
+}
+
+func (r *Resolver) UpdateCar(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   carInput
+	IfMatch *string
+}) (*carResolver, error) {
+	carReq, err := carRequestOf(args.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := r.cars.UpdateCar(ctx, string(args.ID), &carReq, models.ParseETags(value(args.IfMatch)))
+	if err != nil {
+		return nil, err
+	}
+	return &carResolver{car: *car}, nil
+}
+
+func (r *Resolver) DeleteCar(ctx context.Context, args struct {
+	ID      graphql.ID
+	IfMatch *string
+}) (*carResolver, error) {
+	car, err := r.cars.DeleteCar(ctx, string(args.ID), models.ParseETags(value(args.IfMatch)))
+	if err != nil {
+		return nil, err
+	}
+	// the engine of a deleted car may have gone with it
+	return &carResolver{car: *car, includeDeleted: true}, nil
+}
+
+func (r *Resolver) CreateEngine(ctx context.Context, args struct{ Input engineInput }) (*engineResolver, error) {
+	engineReq := engineRequestOf(args.Input)
+
+	engine, err := r.engines.CreateEngine(ctx, &engineReq)
+	if err != nil {
+		return nil, err
+	}
+	return &engineResolver{engine: engine}, nil
+}
+
+func (r *Resolver) UpdateEngine(ctx context.Context, args struct {
+	ID      graphql.ID
+	Input   engineInput
+	IfMatch *string
+}) (*engineResolver, error) {
+	engineReq := engineRequestOf(args.Input)
+
+	engine, err := r.engines.UpdateEngine(ctx, string(args.ID), &engineReq, models.ParseETags(value(args.IfMatch)))
+	if err != nil {
+		return nil, err
+	}
+	return &engineResolver{engine: engine}, nil
+}
+
+func (r *Resolver) DeleteEngine(ctx context.Context, args struct {
+	ID      graphql.ID
+	Cascade string
+	IfMatch *string
+}) (*engineResolver, error) {
+	cascade := models.CascadeMode(strings.ToLower(args.Cascade))
+	if cascade == "none" {
+		cascade = models.CascadeNone
+	}
+
+	engine, err := r.engines.DeleteEngine(ctx, string(args.ID), cascade, models.ParseETags(value(args.IfMatch)))
+	if err != nil {
+		return nil, err
+	}
+	return &engineResolver{engine: engine}, nil
+}
+
+// carRequestOf reads a car input like the json body of a REST request,
+// leaving the checks of the fields to the service.
+func carRequestOf(input carInput) (models.CarRequest, error) {
+	carReq := models.CarRequest{
+		Name:     input.Name,
+		Year:     input.Year,
+		Brand:    input.Brand,
+		FuelType: input.FuelType,
+		Engine: models.Engine{
+			Displacement:  int64(input.Engine.Displacement),
+			NoOfCylinders: int64(input.Engine.NoOfCylinders),
+			CarRange:      int64(input.Engine.CarRange),
+		},
+		Price: models.Money{
+			AmountMinor: int64(input.Price.AmountMinor),
+			Currency:    input.Price.Currency,
+		},
+	}
+
+	id, err := uuid.Parse(string(input.Engine.EngineId))
+	if err != nil {
+		return carReq, apperrors.NewSchemaViolation([]apperrors.FieldError{
+			{Field: "input.engine.engineId", Code: models.CodeInvalid, Message: "engineId must be a uuid"},
+		})
+	}
+	carReq.Engine.EngineId = id
+	return carReq, nil
+}
+
+func engineRequestOf(input engineInput) models.EngineRequest {
+	return models.EngineRequest{
+		Displacement:  int64(input.Displacement),
+		NoOfCylinders: int64(input.NoOfCylinders),
+		CarRange:      int64(input.CarRange),
+	}
+}
+
+// withDeleted marks the context to show soft deleted records when the
+// field asks for them, like ?include_deleted=true.
+func withDeleted(ctx context.Context, include bool) context.Context {
+	if !include {
+		return ctx
+	}
+	return store.WithDeleted(ctx)
+}
+
+func value[T any](pointer *T) T {
+	var zero T
+	if pointer == nil {
+		return zero
+	}
+	return *pointer
+}
+
+func intOf(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
+
+func int64Of(value *Int64) *int64 {
+	if value == nil {
+		return nil
+	}
+	result := int64(*value)
+	return &result
+}
+
+func moneyOf(amount *Int64, currency string) *models.Money {
+	if amount == nil {
+		return nil
+	}
+	return &models.Money{AmountMinor: int64(*amount), Currency: currency}
+}
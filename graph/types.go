@@ -0,0 +1,223 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Int64 is the Int64 scalar. Amounts past 2^53 are exact only when they
+// are written as strings.
+type Int64 int64
+
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+func (n *Int64) UnmarshalGraphQL(input any) error {
+	switch input := input.(type) {
+	case int32:
+		*n = Int64(input)
+	case int:
+		*n = Int64(input)
+	case float64:
+		// numbers of the json variables
+		if input != math.Trunc(input) || math.Abs(input) > 1<<53 {
+			return fmt.Errorf("%v is not a 64 bit integer", input)
+		}
+		*n = Int64(input)
+	case string:
+		value, err := strconv.ParseInt(input, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a 64 bit integer", input)
+		}
+		*n = Int64(value)
+	default:
+		return fmt.Errorf("wrong type for Int64: %T", input)
+	}
+	return nil
+}
+
+func (n Int64) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, int64(n), 10), nil
+}
+
+type carResolver struct {
+	car models.Car
+
+	// the engine of a car read with its deleted records is looked up
+	// among them too
+	includeDeleted bool
+}
+
+func (r *carResolver) ID() graphql.ID {
+	return graphql.ID(r.car.ID.String())
+}
+
+func (r *carResolver) Name() string {
+	return r.car.Name
+}
+
+func (r *carResolver) Year() string {
+	return r.car.Year
+}
+
+func (r *carResolver) Brand() string {
+	return r.car.Brand
+}
+
+func (r *carResolver) FuelType() string {
+	return r.car.FuelType
+}
+
+// Engine goes through the loaders, so the engines of the cars of a list
+// are read in one call instead of one call a car.
+func (r *carResolver) Engine(ctx context.Context) (*engineResolver, error) {
+	if r.car.Engine.EngineId == uuid.Nil {
+		return nil, nil
+	}
+
+	engine, err := loadEngine(ctx, r.car.Engine.EngineId, r.includeDeleted)
+	if err != nil || engine == nil {
+		return nil, err
+	}
+	return &engineResolver{engine: *engine}, nil
+}
+
+func (r *carResolver) Price() *moneyResolver {
+	return &moneyResolver{money: r.car.Price}
+}
+
+func (r *carResolver) ConvertedPrice() *moneyResolver {
+	if r.car.ConvertedPrice == nil {
+		return nil
+	}
+	return &moneyResolver{money: *r.car.ConvertedPrice}
+}
+
+func (r *carResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.car.CreatedAt}
+}
+
+func (r *carResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: r.car.UpdatedAt}
+}
+
+func (r *carResolver) Version() Int64 {
+	return Int64(r.car.Version)
+}
+
+func (r *carResolver) DeletedAt() *graphql.Time {
+	if r.car.DeletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.car.DeletedAt}
+}
+
+func (r *carResolver) Etag() string {
+	return r.car.ETag()
+}
+
+type engineResolver struct {
+	engine models.Engine
+}
+
+func (r *engineResolver) ID() graphql.ID {
+	return graphql.ID(r.engine.EngineId.String())
+}
+
+func (r *engineResolver) Displacement() Int64 {
+	return Int64(r.engine.Displacement)
+}
+
+func (r *engineResolver) NoOfCylinders() Int64 {
+	return Int64(r.engine.NoOfCylinders)
+}
+
+func (r *engineResolver) CarRange() Int64 {
+	return Int64(r.engine.CarRange)
+}
+
+func (r *engineResolver) Version() Int64 {
+	return Int64(r.engine.Version)
+}
+
+func (r *engineResolver) DeletedAt() *graphql.Time {
+	if r.engine.DeletedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.engine.DeletedAt}
+}
+
+func (r *engineResolver) CarCount() *Int64 {
+	if r.engine.CarCount == nil {
+		return nil
+	}
+	count := Int64(*r.engine.CarCount)
+	return &count
+}
+
+func (r *engineResolver) Etag() string {
+	return r.engine.ETag()
+}
+
+type moneyResolver struct {
+	money models.Money
+}
+
+func (r *moneyResolver) AmountMinor() Int64 {
+	return Int64(r.money.AmountMinor)
+}
+
+func (r *moneyResolver) Currency() string {
+	return r.money.Currency
+}
+
+type carPageResolver struct {
+	page           models.CarPage
+	includeDeleted bool
+}
+
+func (r *carPageResolver) Cars() []*carResolver {
+	return carResolvers(r.page.Cars, r.includeDeleted)
+}
+
+func (r *carPageResolver) NextCursor() *string {
+	return optional(r.page.NextCursor)
+}
+
+type enginePageResolver struct {
+	page models.EnginePage
+}
+
+func (r *enginePageResolver) Engines() []*engineResolver {
+	resolvers := make([]*engineResolver, len(r.page.Engines))
+	for i, engine := range r.page.Engines {
+		resolvers[i] = &engineResolver{engine: engine}
+	}
+	return resolvers
+}
+
+func (r *enginePageResolver) NextCursor() *string {
+	return optional(r.page.NextCursor)
+}
+
+func carResolvers(cars []models.Car, includeDeleted bool) []*carResolver {
+	resolvers := make([]*carResolver, len(cars))
+	for i, car := range cars {
+		resolvers[i] = &carResolver{car: car, includeDeleted: includeDeleted}
+	}
+	return resolvers
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+)
+
+// ActorHeader names who is making a request, for the audit log.
+const ActorHeader = "X-Actor"
+
+// Actor records the X-Actor header of each request as the actor of the
+// writes it makes. Requests without it are audited as anonymous.
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/service/purge"
+)
+
+// AdminHandler serves maintenance endpoints.
+type AdminHandler struct {
+	purger *purge.Purger
+}
+
+func NewAdminHandler(purger *purge.Purger) *AdminHandler {
+	return &AdminHandler{purger: purger}
+}
+
+// Purge serves POST /admin/purge, hard deleting the soft deleted records
+// older than the retention right away instead of waiting for the job.
+func (a *AdminHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	result, err := a.purger.Purge(r.Context())
+	if err != nil {
+		log.Println("Error purging deleted records: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(body)
+}
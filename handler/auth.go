@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+)
+
+// Authenticate requires every request to be authenticated by one of the
+// authenticators, tried in order. The principal goes into the request
+// context and is the actor of the writes the request makes.
+func Authenticate(authenticators ...auth.Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var principal *auth.Principal
+			for _, authenticator := range authenticators {
+				var err error
+				principal, err = authenticator.Authenticate(r)
+				if err != nil {
+					log.Println("Error authenticating request: ", err)
+					unauthorized(w, err)
+					return
+				}
+				if principal != nil {
+					break
+				}
+			}
+
+			if principal == nil {
+				unauthorized(w, apperrors.NewUnauthorized("a bearer token or an api key is required"))
+				return
+			}
+
+			ctx := auth.WithPrincipal(r.Context(), *principal)
+			ctx = audit.WithActor(ctx, principal.Subject)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	if apperrors.HTTPStatus(err) == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="car-management"`)
+	}
+	apperrors.WriteHTTP(w, err)
+}
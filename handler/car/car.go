@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
 	"github.com/TheMikeKaisen/CarManagement/models"
 	"github.com/TheMikeKaisen/CarManagement/service"
 	"github.com/gorilla/mux"
@@ -25,14 +27,38 @@ func (c *CarHandler) GetCarById(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	// create a context
-	ctx := r.Context()
+	// create a context, with soft deleted cars when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// ?as_of= reads the car back from its history
+	asOf, err := handler.AsOf(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
 
 	// call GetCarById service
-	car, getErr := c.service.GetCarById(ctx, id)
+	var car *models.Car
+	var getErr error
+	if asOf != nil {
+		car, getErr = c.service.GetCarAsOf(ctx, id, *asOf)
+	} else {
+		car, getErr = c.service.GetCarById(ctx, id)
+	}
 	if getErr != nil {
-		w.WriteHeader(500)
 		log.Println("Server Error: ", getErr)
+		apperrors.WriteHTTP(w, getErr)
+		return
+	}
+
+	// the client copy is still current
+	if handler.NotModified(w, r, car.ETag()) {
 		return
 	}
 
@@ -57,17 +83,25 @@ func (c *CarHandler) GetCarById(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *CarHandler) GetCarByBrand(w http.ResponseWriter, r *http.Request) {
-	// context
-	ctx := r.Context()
+	// create a context, with soft deleted cars when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
 
 	// get the brand and isEngine string from url
 	brand := r.URL.Query().Get("brand")
 	isEngine := r.URL.Query().Get("isEngine") == "true"
 
-	resp, err := c.service.GetCarByBrand(ctx, brand, isEngine)
+	// ?currency= adds the prices converted to that currency
+	currency := r.URL.Query().Get("currency")
+
+	resp, err := c.service.GetCarByBrand(ctx, brand, isEngine, currency)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -102,8 +136,8 @@ func (c *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 
 	err = json.Unmarshal(body, &carBody)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error", err)
+		apperrors.WriteHTTP(w, apperrors.NewBadRequest("request body is not valid json", err))
 		return
 	}
 
@@ -111,8 +145,8 @@ func (c *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	createdCar, err := c.service.CreateCar(ctx, carBody)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Unable to create Car", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -125,6 +159,7 @@ func (c *CarHandler) CreateCar(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", createdCar.ETag())
 	w.WriteHeader(200)
 	_, err = w.Write(car)
 	if err != nil {
@@ -146,8 +181,8 @@ func (c *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
 	var carBody models.CarRequest
 	err = json.Unmarshal(reqBody, &carBody)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error while unmarshalling: ", err)
+		apperrors.WriteHTTP(w, apperrors.NewBadRequest("request body is not valid json", err))
 		return
 	}
 
@@ -156,10 +191,10 @@ func (c *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
 
 	// extract id
 	id := mux.Vars(r)["id"]
-	updatedCar, err := c.service.UpdateCar(ctx, id, &carBody)
+	updatedCar, err := c.service.UpdateCar(ctx, id, &carBody, handler.IfMatch(r))
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error updating the car: ", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -172,12 +207,58 @@ func (c *CarHandler) UpdateCar(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", updatedCar.ETag())
 	w.WriteHeader(200)
 
 	w.Write(response)
 
 }
 
+// PatchCar serves PATCH /cars/{id} with a merge patch or JSON Patch body.
+func (c *CarHandler) PatchCar(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Accept-Patch", handler.AcceptPatch)
+
+	format, err := handler.PatchFormat(r)
+	if err != nil {
+		log.Println("Error reading patch format: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error Reading from request body: ", err)
+		return
+	}
+
+	// create context
+	ctx := r.Context()
+
+	// extract id
+	id := mux.Vars(r)["id"]
+	patchedCar, err := c.service.PatchCar(ctx, id, format, reqBody, handler.IfMatch(r))
+	if err != nil {
+		log.Println("Error patching the car: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the data to send as response
+	response, err := json.Marshal(patchedCar)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", patchedCar.ETag())
+	w.WriteHeader(200)
+
+	w.Write(response)
+}
+
 func (c *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
 	// create a context
 	ctx := r.Context()
@@ -185,10 +266,10 @@ func (c *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
 	// extract id
 	id := mux.Vars(r)["id"]
 
-	deletedCar, err := c.service.DeleteCar(ctx, id)
+	deletedCar, err := c.service.DeleteCar(ctx, id, handler.IfMatch(r))
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error while Deleting the car: ", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -210,3 +291,65 @@ func (c *CarHandler) DeleteCar(w http.ResponseWriter, r *http.Request) {
 	}
 
 }
+
+// RestoreCar serves POST /cars/{id}/restore, undoing a soft delete.
+func (c *CarHandler) RestoreCar(w http.ResponseWriter, r *http.Request) {
+	// create a context
+	ctx := r.Context()
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	restoredCar, err := c.service.RestoreCar(ctx, id)
+	if err != nil {
+		log.Println("Error while restoring the car: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the response
+	response, err := json.Marshal(restoredCar)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", restoredCar.ETag())
+	w.WriteHeader(200)
+	w.Write(response)
+}
+
+// CarHistory serves GET /cars/{id}/history, the audit trail of the car.
+func (c *CarHandler) CarHistory(w http.ResponseWriter, r *http.Request) {
+	// create a context, with the history of a deleted car when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	history, err := c.service.CarHistory(ctx, id)
+	if err != nil {
+		log.Println("Error while reading the car history: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the response
+	response, err := json.Marshal(history)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(response)
+}
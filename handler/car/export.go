@@ -0,0 +1,82 @@
+package car
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/exports"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ExportCars serves GET /exports/cars, the whole filtered car list as csv,
+// ndjson or xlsx picked by ?format= or the Accept header. It takes the
+// filters and sort of GET /cars and streams the cars as they are read.
+func (c *CarHandler) ExportCars(w http.ResponseWriter, r *http.Request) {
+	format, err := exports.Negotiate(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		log.Println("Error negotiating the export format: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// create a context, with soft deleted cars when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	filter, err := parseCarFilter(r.URL.Query())
+	if err != nil {
+		log.Println("Error parsing filter: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// the response only starts with the first car, so an export failing
+	// before that still gets a proper error
+	var out exports.Writer
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", string(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="cars.`+format.Extension()+`"`)
+		w.WriteHeader(200)
+
+		var err error
+		out, err = exports.NewWriter(format, w)
+		return err
+	}
+
+	err = c.service.ExportCars(ctx, filter, func(car models.Car) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return out.Write(car)
+	})
+	if err != nil {
+		log.Println("Error exporting cars: ", err)
+		if !started {
+			apperrors.WriteHTTP(w, err)
+		}
+		// otherwise the client gets a truncated file, there is no way to
+		// change the status any more
+		return
+	}
+
+	// an empty export still has its header row
+	if !started {
+		if err := start(); err != nil {
+			log.Println("Error exporting cars: ", err)
+			return
+		}
+	}
+	if err := out.Close(); err != nil {
+		log.Println("Error finishing the export: ", err)
+	}
+}
@@ -0,0 +1,60 @@
+package car
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/TheMikeKaisen/CarManagement/imports"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ImportCars serves POST /imports?dry_run=&atomic=, creating the cars of a
+// text/csv or application/x-ndjson body and answering with a report of
+// every row.
+func (c *CarHandler) ImportCars(w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	format, ok := imports.ParseFormat(contentType)
+	if !ok {
+		apperrors.WriteHTTP(w, apperrors.NewUnsupportedMediaType(contentType, imports.Accepted...))
+		return
+	}
+
+	p := handler.NewQueryParser(r.URL.Query())
+	opts := models.ImportOptions{
+		DryRun: p.Bool("dry_run"),
+		Atomic: p.Bool("atomic"),
+	}
+	if err := p.Err(); err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	rows, err := imports.Read(format, r.Body)
+	if err != nil {
+		log.Println("Error reading the import: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	report, err := c.service.ImportCars(r.Context(), rows, opts)
+	if err != nil {
+		log.Println("Error importing cars: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(body)
+}
@@ -0,0 +1,88 @@
+package car
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ListCars serves GET /cars?brand=&fuel_type=&min_year=&max_price=&sort=-price,name&limit=&cursor=
+// Price bounds are amounts in ?price_currency= (USD by default) and
+// ?currency= adds every price converted to that currency. Sorting by price
+// needs ?price_currency=, prices only compare within one currency.
+func (c *CarHandler) ListCars(w http.ResponseWriter, r *http.Request) {
+	// create a context, with soft deleted cars when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	filter, err := parseCarFilter(r.URL.Query())
+	if err != nil {
+		log.Println("Error parsing filter: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	page, err := c.service.ListCars(ctx, filter)
+	if err != nil {
+		log.Println("Error listing cars: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(body)
+}
+
+func parseCarFilter(query url.Values) (models.CarFilter, error) {
+	p := handler.NewQueryParser(query)
+
+	// the price bounds are decimal amounts of their currency
+	boundCurrency := query.Get("price_currency")
+	if boundCurrency == "" {
+		boundCurrency = models.DefaultCurrency
+	}
+
+	filter := models.CarFilter{
+		Brand:    query.Get("brand"),
+		FuelType: query.Get("fuel_type"),
+
+		MinYear:       p.Int("min_year"),
+		MaxYear:       p.Int("max_year"),
+		PriceCurrency: query.Get("price_currency"),
+		MinPrice:      p.Money("min_price", boundCurrency),
+		MaxPrice:      p.Money("max_price", boundCurrency),
+		Currency:      query.Get("currency"),
+
+		MinDisplacement:  p.Int64("min_displacement"),
+		MaxDisplacement:  p.Int64("max_displacement"),
+		MinNoOfCylinders: p.Int64("min_no_of_cylinders"),
+		MaxNoOfCylinders: p.Int64("max_no_of_cylinders"),
+		MinCarRange:      p.Int64("min_car_range"),
+		MaxCarRange:      p.Int64("max_car_range"),
+
+		Sort:   models.ParseSort(query.Get("sort")),
+		Cursor: query.Get("cursor"),
+	}
+	if limit := p.Int("limit"); limit != nil {
+		filter.Limit = *limit
+	}
+
+	return filter, p.Err()
+}
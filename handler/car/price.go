@@ -0,0 +1,69 @@
+package car
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/gorilla/mux"
+)
+
+// CarPrices serves GET /cars/{id}/prices, every price the car has had.
+func (c *CarHandler) CarPrices(w http.ResponseWriter, r *http.Request) {
+	// create a context, with soft deleted cars when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Println("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	timeline, err := c.service.CarPrices(ctx, id)
+	if err != nil {
+		log.Println("Error while reading the car prices: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the response
+	response, err := json.Marshal(timeline)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(response)
+}
+
+// PriceStats serves GET /cars/prices/stats?group_by=brand|fuel_type|year.
+func (c *CarHandler) PriceStats(w http.ResponseWriter, r *http.Request) {
+	// create a context
+	ctx := r.Context()
+
+	report, err := c.service.PriceStats(ctx, r.URL.Query().Get("group_by"))
+	if err != nil {
+		log.Println("Error while computing the price stats: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the response
+	response, err := json.Marshal(report)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Println("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(response)
+}
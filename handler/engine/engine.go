@@ -6,6 +6,8 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
 	"github.com/TheMikeKaisen/CarManagement/models"
 	"github.com/TheMikeKaisen/CarManagement/service"
 	"github.com/gorilla/mux"
@@ -35,15 +37,15 @@ func (e *EngineHandler) CreateEngine(w http.ResponseWriter, r *http.Request) {
 	var body models.EngineRequest
 	err = json.Unmarshal(engineBody, &body)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Print("Error unmarshaling: ", err)
+		apperrors.WriteHTTP(w, apperrors.NewBadRequest("request body is not valid json", err))
 		return
 	}
 
 	response, err := e.service.CreateEngine(ctx, &body)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Print("Error creating engine: ", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -56,6 +58,7 @@ func (e *EngineHandler) CreateEngine(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", response.ETag())
 	w.WriteHeader(200)
 
 	_, err = w.Write(responseBody)
@@ -68,16 +71,39 @@ func (e *EngineHandler) CreateEngine(w http.ResponseWriter, r *http.Request) {
 }
 
 func (e *EngineHandler) GetEngineById(w http.ResponseWriter, r *http.Request) {
-	// create context
-	ctx := r.Context()
+	// create context, with soft deleted engines when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Print("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
 
 	// extract id
 	id := mux.Vars(r)["id"]
 
-	resp, err := e.service.GetEngineById(ctx, id)
+	// ?as_of= reads the engine back from its history
+	asOf, err := handler.AsOf(r)
+	if err != nil {
+		log.Print("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	var resp models.Engine
+	if asOf != nil {
+		resp, err = e.service.GetEngineAsOf(ctx, id, *asOf)
+	} else {
+		resp, err = e.service.GetEngineById(ctx, id)
+	}
 	if err != nil {
-		w.WriteHeader(500)
 		log.Print("Error while getting the engine: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// the client copy is still current
+	if handler.NotModified(w, r, resp.ETag()) {
 		return
 	}
 
@@ -118,15 +144,15 @@ func (e *EngineHandler) UpdateEngine(w http.ResponseWriter, r *http.Request) {
 	var engineReqBody models.EngineRequest
 	err = json.Unmarshal(reqBody, &engineReqBody)
 	if err != nil {
-		w.WriteHeader(500)
 		log.Print("Error while marshaling: ", err)
+		apperrors.WriteHTTP(w, apperrors.NewBadRequest("request body is not valid json", err))
 		return
 	}
 
-	respBody, err := e.service.UpdateEngine(ctx, id, &engineReqBody)
+	respBody, err := e.service.UpdateEngine(ctx, id, &engineReqBody, handler.IfMatch(r))
 	if err != nil {
-		w.WriteHeader(500)
 		log.Print("Error while updating the engine: ", err)
+		apperrors.WriteHTTP(w, err)
 		return
 	}
 
@@ -139,6 +165,7 @@ func (e *EngineHandler) UpdateEngine(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", respBody.ETag())
 	w.WriteHeader(200)
 
 	_, err = w.Write(engineBody)
@@ -150,6 +177,56 @@ func (e *EngineHandler) UpdateEngine(w http.ResponseWriter, r *http.Request) {
 
 }
 
+// PatchEngine serves PATCH /engines/{id} with a merge patch or JSON Patch body.
+func (e *EngineHandler) PatchEngine(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Accept-Patch", handler.AcceptPatch)
+
+	// create the context
+	ctx := r.Context()
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	format, err := handler.PatchFormat(r)
+	if err != nil {
+		log.Print("Error while reading the patch format: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Print("Error while reading the request body: ", err)
+		return
+	}
+
+	respBody, err := e.service.PatchEngine(ctx, id, format, reqBody, handler.IfMatch(r))
+	if err != nil {
+		log.Print("Error while patching the engine: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the data
+	engineBody, err := json.Marshal(respBody)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Print("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", respBody.ETag())
+	w.WriteHeader(200)
+
+	_, err = w.Write(engineBody)
+	if err != nil {
+		log.Print("Error while writing the response: ", err)
+		return
+	}
+}
+
 func (e *EngineHandler) DeleteEngine(w http.ResponseWriter, r *http.Request){
 	// create context
 	ctx := r.Context()
@@ -157,10 +234,13 @@ func (e *EngineHandler) DeleteEngine(w http.ResponseWriter, r *http.Request){
 	// extract id
 	id := mux.Vars(r)["id"]
 
-	deletedEngine, err := e.service.DeleteEngine(ctx, id)
+	// ?cascade=detach|delete decides what happens to the cars using the engine
+	cascade := models.CascadeMode(r.URL.Query().Get("cascade"))
+
+	deletedEngine, err := e.service.DeleteEngine(ctx, id, cascade, handler.IfMatch(r))
 	if err != nil {
-		w.WriteHeader(500)
 		log.Println("Error deleting the engine: ", err)
+		apperrors.WriteHTTP(w, err)
 		return 
 	}
 
@@ -176,3 +256,65 @@ func (e *EngineHandler) DeleteEngine(w http.ResponseWriter, r *http.Request){
 	w.WriteHeader(200)
 	w.Write(responseBody)
 }
+
+// RestoreEngine serves POST /engines/{id}/restore, undoing a soft delete.
+func (e *EngineHandler) RestoreEngine(w http.ResponseWriter, r *http.Request) {
+	// create context
+	ctx := r.Context()
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	restoredEngine, err := e.service.RestoreEngine(ctx, id)
+	if err != nil {
+		log.Print("Error while restoring the engine: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the data
+	engineBody, err := json.Marshal(restoredEngine)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Print("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", restoredEngine.ETag())
+	w.WriteHeader(200)
+	w.Write(engineBody)
+}
+
+// EngineHistory serves GET /engines/{id}/history, the audit trail of the engine.
+func (e *EngineHandler) EngineHistory(w http.ResponseWriter, r *http.Request) {
+	// create context, with the history of a deleted engine when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Print("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// extract id
+	id := mux.Vars(r)["id"]
+
+	history, err := e.service.EngineHistory(ctx, id)
+	if err != nil {
+		log.Print("Error while reading the engine history: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the data
+	historyBody, err := json.Marshal(history)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Print("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(historyBody)
+}
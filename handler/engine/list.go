@@ -0,0 +1,78 @@
+package engine
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ListEngines serves GET /engines?min_displacement=&max_no_of_cylinders=&include=car_count&sort=&limit=&cursor=
+func (e *EngineHandler) ListEngines(w http.ResponseWriter, r *http.Request) {
+	// create context, with soft deleted engines when asked for
+	ctx, err := handler.IncludeDeleted(r)
+	if err != nil {
+		log.Print("Error parsing query: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	filter, err := parseEngineFilter(r.URL.Query())
+	if err != nil {
+		log.Print("Error parsing filter: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	page, err := e.service.ListEngines(ctx, filter)
+	if err != nil {
+		log.Print("Error listing engines: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+
+	// marshal the data
+	body, err := json.Marshal(page)
+	if err != nil {
+		w.WriteHeader(500)
+		log.Print("Error while marshaling: ", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write(body)
+}
+
+func parseEngineFilter(query url.Values) (models.EngineFilter, error) {
+	p := handler.NewQueryParser(query)
+
+	filter := models.EngineFilter{
+		MinDisplacement:  p.Int64("min_displacement"),
+		MaxDisplacement:  p.Int64("max_displacement"),
+		MinNoOfCylinders: p.Int64("min_no_of_cylinders"),
+		MaxNoOfCylinders: p.Int64("max_no_of_cylinders"),
+		MinCarRange:      p.Int64("min_car_range"),
+		MaxCarRange:      p.Int64("max_car_range"),
+
+		Sort:   models.ParseSort(query.Get("sort")),
+		Cursor: query.Get("cursor"),
+	}
+	if limit := p.Int("limit"); limit != nil {
+		filter.Limit = *limit
+	}
+
+	// include=car_count asks for the number of cars using each engine
+	for _, include := range strings.Split(query.Get("include"), ",") {
+		if strings.TrimSpace(include) == "car_count" {
+			filter.IncludeCarCount = true
+		}
+	}
+
+	return filter, p.Err()
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// IfMatch reads the If-Match header a write is conditioned on.
+func IfMatch(r *http.Request) models.ETags {
+	return models.ParseETags(r.Header.Get("If-Match"))
+}
+
+// NotModified sets the ETag of a read and answers 304 when the copy named
+// in If-None-Match is still current. It reports whether it answered.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+
+	ifNoneMatch := models.ParseETags(r.Header.Get("If-None-Match"))
+	if !ifNoneMatch.MatchWeak(etag) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/events"
+)
+
+const (
+	// keepAlive is how often an idle stream gets a comment, so proxies do
+	// not close it
+	keepAlive = 15 * time.Second
+
+	// retry is how long a client waits before it reconnects, in milliseconds
+	retry = 3000
+)
+
+var resetData = []byte(`{"reason":"the events since the last event id are no longer buffered, read the catalog again"}`)
+
+type EventsHandler struct {
+	broker *events.Broker
+}
+
+func NewEventsHandler(broker *events.Broker) *EventsHandler {
+	return &EventsHandler{broker: broker}
+}
+
+// Stream serves GET /events as server-sent events. A client resuming with
+// the Last-Event-ID header, or ?last_event_id= where it cannot set headers,
+// first gets the buffered events after that one. When some of them are no
+// longer buffered it gets a reset event instead, telling it to read the
+// catalog again.
+func (h *EventsHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	subscription, err := h.broker.Subscribe(r.Context(), lastEventID)
+	if err != nil {
+		log.Println("Error subscribing to the events: ", err)
+		apperrors.WriteHTTP(w, err)
+		return
+	}
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// keep reverse proxies from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(200)
+
+	flusher := http.NewResponseController(w)
+	fmt.Fprintf(w, "retry: %d\n\n", retry)
+
+	if subscription.Reset {
+		// the id of the reset is the newest event, later events follow it
+		writeEvent(w, events.Event{ID: subscription.LastID, Type: events.Reset, Data: resetData})
+	}
+	for _, event := range subscription.Replay {
+		writeEvent(w, event)
+	}
+	if err := flusher.Flush(); err != nil {
+		log.Println("Error flushing the event stream: ", err)
+		return
+	}
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// dropped or shutting down, the client reconnects
+				return
+			}
+			writeEvent(w, event)
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+
+		if err := flusher.Flush(); err != nil {
+			log.Println("Error flushing the event stream: ", err)
+			return
+		}
+	}
+}
+
+// writeEvent writes one event. Its data is json on a single line.
+func writeEvent(w http.ResponseWriter, event events.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+}
@@ -0,0 +1,52 @@
+package health
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/driver"
+)
+
+type HealthHandler struct {
+	db *sql.DB
+}
+
+func NewHealthHandler(db *sql.DB) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// Liveness only tells that the process is serving requests.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+	w.Write([]byte(`{"status":"up"}`))
+}
+
+// Readiness pings the database, answering 503 while it is unreachable.
+// Backends without a database, like the in-memory store, are always ready.
+// The probe needs no credentials, so it only tells whether the database is
+// up; why it is down and the pool statistics go to the log.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	if h.db == nil {
+		h.Liveness(w, r)
+		return
+	}
+
+	health := driver.CheckHealth(r.Context(), h.db)
+	if health.IsHealthy() {
+		h.Liveness(w, r)
+		return
+	}
+
+	details, err := json.Marshal(health)
+	if err != nil {
+		log.Println("Error marshaling health: ", err)
+	}
+	log.Println("Database not ready: ", string(details))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(503)
+	w.Write([]byte(`{"status":"down"}`))
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/patch"
+)
+
+// AcceptPatch is advertised in the Accept-Patch header of PATCH responses.
+const AcceptPatch = string(patch.FormatMergePatch) + ", " + string(patch.FormatJSONPatch)
+
+// PatchFormat reads the patch format of a PATCH request from its
+// Content-Type, answering 415 for anything else.
+func PatchFormat(r *http.Request) (patch.Format, error) {
+	contentType := r.Header.Get("Content-Type")
+
+	format, ok := patch.ParseFormat(contentType)
+	if !ok {
+		return "", apperrors.NewUnsupportedMediaType(contentType, string(patch.FormatMergePatch), string(patch.FormatJSONPatch))
+	}
+	return format, nil
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+// QueryParser reads optional typed query parameters, remembering the
+// first one that could not be parsed.
+type QueryParser struct {
+	query url.Values
+	err   error
+}
+
+func NewQueryParser(query url.Values) *QueryParser {
+	return &QueryParser{query: query}
+}
+
+func (p *QueryParser) Int(name string) *int {
+	value := p.Int64(name)
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
+
+func (p *QueryParser) Int64(name string) *int64 {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		p.fail(name, err)
+		return nil
+	}
+	return &value
+}
+
+func (p *QueryParser) Float(name string) *float64 {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		p.fail(name, err)
+		return nil
+	}
+	return &value
+}
+
+// Bool reads an optional true/false parameter.
+func (p *QueryParser) Bool(name string) bool {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil && p.err == nil {
+		p.err = apperrors.NewBadRequest("query parameter "+name+" must be true or false", err)
+	}
+	return value
+}
+
+// Time reads an optional RFC 3339 timestamp.
+func (p *QueryParser) Time(name string) *time.Time {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		if p.err == nil {
+			p.err = apperrors.NewBadRequest("query parameter "+name+" must be an RFC 3339 timestamp", err)
+		}
+		return nil
+	}
+	return &value
+}
+
+// Money reads an optional decimal amount in major units of currency.
+func (p *QueryParser) Money(name string, currency string) *models.Money {
+	raw := p.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+	value, err := models.ParseMoney(raw, currency)
+	if err != nil {
+		if p.err == nil {
+			p.err = apperrors.NewBadRequest("query parameter "+name+" must be an amount in "+currency, err)
+		}
+		return nil
+	}
+	return &value
+}
+
+// Err returns the first parameter that could not be parsed.
+func (p *QueryParser) Err() error {
+	return p.err
+}
+
+func (p *QueryParser) fail(name string, err error) {
+	if p.err == nil {
+		p.err = apperrors.NewBadRequest("query parameter "+name+" must be a number", err)
+	}
+}
+
+// IncludeDeleted returns the context of r, marked to show soft deleted
+// records when the request has ?include_deleted=true.
+func IncludeDeleted(r *http.Request) (context.Context, error) {
+	p := NewQueryParser(r.URL.Query())
+	include := p.Bool("include_deleted")
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+
+	if !include {
+		return r.Context(), nil
+	}
+	return store.WithDeleted(r.Context()), nil
+}
+
+// AsOf returns the time of ?as_of=, asking for a record as it was then,
+// or nil for the current record.
+func AsOf(r *http.Request) (*time.Time, error) {
+	p := NewQueryParser(r.URL.Query())
+	asOf := p.Time("as_of")
+	return asOf, p.Err()
+}
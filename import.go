@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/TheMikeKaisen/CarManagement/imports"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/service"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+)
+
+// importActor is the actor the audit log records for command line imports.
+const importActor = "import-cli"
+
+// runImport implements `import [-dry-run] [-atomic] [-format csv|ndjson]
+// [-actor name] file`, printing the report of every row. A file of "-" is
+// read from stdin.
+func runImport(ctx context.Context, cars service.CarServiceInterface, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "check every row without writing")
+	atomic := flags.Bool("atomic", false, "write every row or none")
+	formatName := flags.String("format", "", "csv or ndjson, guessed from the file name when empty")
+	actor := flags.String("actor", importActor, "actor recorded in the audit log")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import [-dry-run] [-atomic] [-format csv|ndjson] [-actor name] file")
+	}
+	path := flags.Arg(0)
+
+	var format imports.Format
+	switch *formatName {
+	case "csv":
+		format = imports.FormatCSV
+	case "ndjson":
+		format = imports.FormatNDJSON
+	case "":
+		var ok bool
+		if format, ok = imports.FormatOf(path); !ok {
+			return fmt.Errorf("cannot tell the format of %s, use -format csv|ndjson", path)
+		}
+	default:
+		return fmt.Errorf("unknown format %q, use csv or ndjson", *formatName)
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	rows, err := imports.Read(format, input)
+	if err != nil {
+		return err
+	}
+
+	ctx = audit.WithActor(ctx, *actor)
+	report, err := cars.ImportCars(ctx, rows, models.ImportOptions{DryRun: *dryRun, Atomic: *atomic})
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d rows failed", report.Failed, report.Total)
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+// Format is the media type of an import file.
+type Format string
+
+const (
+	// FormatCSV is a spreadsheet export with a header row naming the columns
+	FormatCSV Format = "text/csv"
+	// FormatNDJSON has one car request per line, as sent to POST /cars
+	FormatNDJSON Format = "application/x-ndjson"
+)
+
+// Accepted lists the formats for error messages.
+var Accepted = []string{string(FormatCSV), string(FormatNDJSON)}
+
+// Columns lists the csv columns. price is a decimal amount in currency,
+// USD when the column is missing or empty. The engine is either engine_id
+// or the three specs.
+var Columns = []string{
+	"name", "year", "brand", "fuel_type", "price", "currency",
+	"engine_id", "displacement", "no_of_cylinders", "car_range",
+}
+
+// ParseFormat reads the import format from a Content-Type header.
+func ParseFormat(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case string(FormatCSV):
+		return FormatCSV, true
+	case string(FormatNDJSON), "application/ndjson", "application/jsonl":
+		return FormatNDJSON, true
+	}
+	return "", false
+}
+
+// FormatOf guesses the import format from the extension of a file name.
+func FormatOf(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, true
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, true
+	}
+	return "", false
+}
+
+// Read reads every row of an import file. Rows that cannot be read into a
+// car carry the reason in their Err, a file that cannot be read at all is a
+// bad request.
+func Read(format Format, r io.Reader) ([]models.ImportRow, error) {
+	var rows []models.ImportRow
+	var err error
+
+	switch format {
+	case FormatCSV:
+		rows, err = ReadCSV(r)
+	case FormatNDJSON:
+		rows, err = ReadNDJSON(r)
+	default:
+		return nil, apperrors.NewUnsupportedMediaType(string(format), Accepted...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, apperrors.NewBadRequest("the import has no rows", nil)
+	}
+	return rows, nil
+}
+
+// ReadCSV reads a csv file whose header row names some of Columns, in any
+// order and case.
+func ReadCSV(r io.Reader) ([]models.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	// rows with missing or extra fields are reported on their own
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperrors.NewBadRequest("the csv header cannot be read", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(Columns, column) {
+			return nil, apperrors.NewBadRequest(fmt.Sprintf("unknown csv column %q, use %s", column, strings.Join(Columns, ", ")), nil)
+		}
+		if _, ok := index[column]; ok {
+			return nil, apperrors.NewBadRequest(fmt.Sprintf("csv column %q is repeated", column), nil)
+		}
+		index[column] = i
+	}
+
+	var rows []models.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// after a broken quote the rest of the file cannot be trusted
+			return nil, apperrors.NewBadRequest("the csv file cannot be read", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, csvRow(line, record, index))
+	}
+
+	return rows, nil
+}
+
+// csvRow maps the fields of one record onto a car request.
+func csvRow(line int, record []string, index map[string]int) models.ImportRow {
+	get := func(column string) string {
+		if i, ok := index[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var fields []apperrors.FieldError
+	fail := func(field string, code string, message string) {
+		fields = append(fields, apperrors.FieldError{Field: field, Code: code, Message: message})
+	}
+	number := func(column string) int64 {
+		raw := get(column)
+		if raw == "" {
+			return 0
+		}
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			fail("engine."+column, models.CodeNotANumber, column+" must be a whole number")
+		}
+		return value
+	}
+
+	if len(record) > len(index) {
+		fail("", models.CodeInvalid, fmt.Sprintf("row has %d fields but the header names %d columns", len(record), len(index)))
+	}
+
+	row := models.ImportRow{
+		Line: line,
+		Car: models.CarRequest{
+			Name:     get("name"),
+			Year:     get("year"),
+			Brand:    get("brand"),
+			FuelType: get("fuel_type"),
+			Engine: models.Engine{
+				Displacement:  number("displacement"),
+				NoOfCylinders: number("no_of_cylinders"),
+				CarRange:      number("car_range"),
+			},
+		},
+	}
+
+	if raw := get("engine_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			fail("engine.engine_id", models.CodeInvalid, "engine_id must be a uuid")
+		}
+		row.Car.Engine.EngineId = id
+	}
+
+	currency := get("currency")
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if raw := get("price"); raw == "" {
+		row.Car.Price.Currency = currency
+	} else if price, err := models.ParseMoney(raw, currency); err != nil {
+		fail("price", models.CodeInvalid, err.Error())
+	} else {
+		row.Car.Price = price
+	}
+
+	if len(fields) > 0 {
+		row.Err = apperrors.NewFieldValidation(fields)
+	}
+	return row
+}
+
+// ReadNDJSON reads one car request per line, skipping blank lines.
+func ReadNDJSON(r io.Reader) ([]models.ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	// a car is small, but leave room for generous names
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var rows []models.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := models.ImportRow{Line: line}
+		if err := json.Unmarshal(text, &row.Car); err != nil {
+			row.Err = apperrors.NewFieldValidation([]apperrors.FieldError{
+				{Field: "", Code: models.CodeInvalid, Message: "line is not a valid car: " + err.Error()},
+			})
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperrors.NewBadRequest("the ndjson file cannot be read", err)
+	}
+
+	return rows, nil
+}
@@ -1 +1,309 @@
-package main
\ No newline at end of file
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/driver"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/exchange"
+	"github.com/TheMikeKaisen/CarManagement/graph"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	adminHandler "github.com/TheMikeKaisen/CarManagement/handler/admin"
+	carHandler "github.com/TheMikeKaisen/CarManagement/handler/car"
+	engineHandler "github.com/TheMikeKaisen/CarManagement/handler/engine"
+	eventsHandler "github.com/TheMikeKaisen/CarManagement/handler/events"
+	healthHandler "github.com/TheMikeKaisen/CarManagement/handler/health"
+	"github.com/TheMikeKaisen/CarManagement/openapi"
+	"github.com/TheMikeKaisen/CarManagement/rpc"
+	carService "github.com/TheMikeKaisen/CarManagement/service/car"
+	engineService "github.com/TheMikeKaisen/CarManagement/service/engine"
+	"github.com/TheMikeKaisen/CarManagement/service/purge"
+)
+
+// all routes are mounted under this prefix
+const apiPrefix = "/api/v1"
+
+type config struct {
+	httpAddr        string
+	grpcAddr        string
+	shutdownTimeout time.Duration
+	migrateOnStart  bool
+	storeBackend    string
+	sqlitePath      string
+	db              driver.Config
+
+	// soft deleted records older than purgeRetention are removed every
+	// purgeInterval, 0 turns the background purge off
+	purgeRetention time.Duration
+	purgeInterval  time.Duration
+
+	// json rate table used to convert listed prices, none when empty
+	exchangeRatesFile string
+
+	// api requests need a bearer token or an api key unless authDisabled,
+	// tokens are only accepted when a key set is configured. The memory
+	// backend cannot hold api keys made by `apikey create`, so it refuses
+	// to start without a key set unless authDisabled.
+	authDisabled bool
+	jwtKeysFile  string
+	jwtIssuer    string
+	jwtAudience  string
+
+	// the largest json body and imported file a request may send
+	maxBodyBytes   int64
+	maxImportBytes int64
+
+	// how many of the last events a client of /events can resume from
+	eventsBuffer int
+}
+
+const (
+	defaultMaxBodyBytes   = 1 << 20
+	defaultMaxImportBytes = 32 << 20
+	defaultEventsBuffer   = 1000
+)
+
+func loadConfig() config {
+	cfg := config{
+		httpAddr:        ":" + getEnv("PORT", "8080"),
+		grpcAddr:        ":" + getEnv("GRPC_PORT", "9090"),
+		shutdownTimeout: 15 * time.Second,
+		migrateOnStart:  os.Getenv("MIGRATE_ON_START") == "true",
+		storeBackend:    getEnv("STORE_BACKEND", "postgres"),
+		sqlitePath:      getEnv("SQLITE_PATH", "car_management.db"),
+		db:              driver.ConfigFromEnv(),
+		purgeRetention:  30 * 24 * time.Hour,
+		purgeInterval:   time.Hour,
+
+		exchangeRatesFile: os.Getenv("EXCHANGE_RATES_FILE"),
+
+		authDisabled: os.Getenv("AUTH_DISABLED") == "true",
+		jwtKeysFile:  os.Getenv("JWT_KEYS_FILE"),
+		jwtIssuer:    os.Getenv("JWT_ISSUER"),
+		jwtAudience:  os.Getenv("JWT_AUDIENCE"),
+
+		maxBodyBytes:   defaultMaxBodyBytes,
+		maxImportBytes: defaultMaxImportBytes,
+		eventsBuffer:   defaultEventsBuffer,
+	}
+
+	if timeout, err := time.ParseDuration(os.Getenv("SHUTDOWN_TIMEOUT")); err == nil {
+		cfg.shutdownTimeout = timeout
+	}
+	if retention, err := time.ParseDuration(os.Getenv("PURGE_RETENTION")); err == nil {
+		cfg.purgeRetention = retention
+	}
+	if interval, err := time.ParseDuration(os.Getenv("PURGE_INTERVAL")); err == nil {
+		cfg.purgeInterval = interval
+	}
+	if limit, err := strconv.ParseInt(os.Getenv("MAX_BODY_BYTES"), 10, 64); err == nil && limit > 0 {
+		cfg.maxBodyBytes = limit
+	}
+	if limit, err := strconv.ParseInt(os.Getenv("MAX_IMPORT_BYTES"), 10, 64); err == nil && limit > 0 {
+		cfg.maxImportBytes = limit
+	}
+	if size, err := strconv.Atoi(os.Getenv("EVENTS_BUFFER")); err == nil && size >= 0 {
+		cfg.eventsBuffer = size
+	}
+
+	return cfg
+}
+
+func main() {
+	cfg := loadConfig()
+
+	// cancelled on SIGINT / SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// `openapi [check]` prints the api document or checks the handlers
+	// against it, on its own in-memory store, and exits
+	if len(os.Args) > 1 && os.Args[1] == "openapi" {
+		if err := runOpenAPI(ctx, os.Args[2:]); err != nil {
+			log.Fatal("OpenAPI check failed: ", err)
+		}
+		return
+	}
+
+	backend, err := openBackend(ctx, cfg)
+	if err != nil {
+		log.Fatal("Error opening the store: ", err)
+	}
+	defer backend.Close()
+
+	// `migrate up|down|status` manages the schema and exits
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, backend.db, os.Args[2:]); err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		return
+	}
+
+	if cfg.migrateOnStart && backend.db != nil {
+		if err := runMigrate(ctx, backend.db, []string{"up"}); err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+	}
+
+	// `apikey create|list|revoke` manages the api keys and exits
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		if backend.db == nil {
+			log.Fatal("API key command failed: the ", cfg.storeBackend, " backend forgets its api keys when the command exits, use postgres or sqlite")
+		}
+		if err := runAPIKey(ctx, backend.apiKeys, os.Args[2:]); err != nil {
+			log.Fatal("API key command failed: ", err)
+		}
+		return
+	}
+
+	var rates *exchange.Rates
+	if cfg.exchangeRatesFile != "" {
+		rates, err = exchange.Load(cfg.exchangeRatesFile)
+		if err != nil {
+			log.Fatal("Error loading the exchange rates: ", err)
+		}
+	}
+
+	// stores -> services -> handlers, the services check every call
+	// against the role policy
+	policy := auth.DefaultPolicy()
+	broker := events.NewBroker(cfg.eventsBuffer, policy)
+	carSvc := carService.NewCarService(backend.cars, rates, policy, broker)
+	engineSvc := engineService.NewEngineStore(backend.engines, policy, broker)
+
+	// `import file` loads cars from a csv or ndjson file and exits
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(ctx, carSvc, os.Args[2:]); err != nil {
+			log.Fatal("Import failed: ", err)
+		}
+		return
+	}
+
+	// hard delete old tombstones in the background
+	purger := purge.NewPurger(backend.cars, backend.engines, cfg.purgeRetention, policy)
+	if cfg.purgeInterval > 0 {
+		go purger.Run(ctx, cfg.purgeInterval)
+	}
+
+	// the api is described by an openapi document built from the same
+	// route table as the router
+	endpoints := apiEndpoints(
+		carHandler.NewCarHandler(carSvc),
+		engineHandler.NewCarHandler(engineSvc),
+		adminHandler.NewAdminHandler(purger),
+	)
+	doc := openapi.Build(apiInfo, apiPrefix, endpoints, newGenerator())
+
+	// writes through the api are audited under the authenticated principal,
+	// or the X-Actor header when authentication is off
+	authenticate := handler.Actor
+	authenticateCall := rpc.Actor
+	if cfg.authDisabled {
+		log.Println("Authentication is disabled, every api request is accepted")
+	} else {
+		authenticators, err := newAuthenticators(cfg, backend)
+		if err != nil {
+			log.Fatal("Error setting up authentication: ", err)
+		}
+		authenticate = handler.Authenticate(authenticators...)
+		authenticateCall = rpc.Authenticate(authenticators...)
+	}
+
+	// requests are checked against the document once the caller is known
+	validate := openapi.RequestValidator(doc, cfg.maxBodyBytes, cfg.maxImportBytes)
+
+	// the same services as a GraphQL api
+	graphQL := graph.NewHandler(carSvc, engineSvc, cfg.maxBodyBytes)
+
+	router := newRouter(healthHandler.NewHealthHandler(backend.sqlDB()), endpoints, doc, graphQL, eventsHandler.NewEventsHandler(broker), authenticate, validate)
+
+	server := &http.Server{
+		Addr:              cfg.httpAddr,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	// event streams never finish on their own, end them for the shutdown
+	server.RegisterOnShutdown(broker.Close)
+
+	go func() {
+		log.Println("Server listening on", cfg.httpAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal("Server error: ", err)
+		}
+	}()
+
+	// the same services over gRPC, on their own port
+	grpcServer := rpc.NewServer(carSvc, engineSvc, authenticateCall)
+	listener, err := net.Listen("tcp", cfg.grpcAddr)
+	if err != nil {
+		log.Fatal("Error listening for gRPC: ", err)
+	}
+	go func() {
+		log.Println("gRPC server listening on", cfg.grpcAddr)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatal("gRPC server error: ", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down server...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Println("Error during shutdown: ", err)
+	}
+
+	// calls still running past the timeout are cut off
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+}
+
+// newAuthenticators accepts api keys always and bearer tokens when a key
+// set is configured. Api keys of a backend without a database only live as
+// long as the server, and none can be created while it runs, so such a
+// backend needs the key set or every request would be refused.
+func newAuthenticators(cfg config, b backend) ([]auth.Authenticator, error) {
+	if b.db == nil && cfg.jwtKeysFile == "" {
+		return nil, fmt.Errorf("the %s backend cannot keep api keys, set JWT_KEYS_FILE or AUTH_DISABLED=true", cfg.storeBackend)
+	}
+
+	authenticators := []auth.Authenticator{}
+
+	if cfg.jwtKeysFile != "" {
+		keySet, err := auth.LoadKeySet(cfg.jwtKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, auth.NewJWTAuthenticator(keySet, cfg.jwtIssuer, cfg.jwtAudience))
+	}
+
+	return append(authenticators, auth.NewAPIKeyAuthenticator(b.apiKeys)), nil
+}
+
+func getEnv(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a stored api key. Only the hash of the key is kept, Prefix is
+// enough of its start to recognise it in a list.
+type APIKey struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"-"`
+	Roles     []string   `json:"roles"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+func ValidateAPIKeyName(name string) error {
+	var v violations
+
+	if strings.TrimSpace(name) == "" {
+		v.add("name", CodeRequired, "name is required")
+	}
+	return v.err()
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// entities recorded in the audit log
+const (
+	EntityCar    = "car"
+	EntityEngine = "engine"
+)
+
+// operations recorded in the audit log
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+	OperationPurge   = "purge"
+)
+
+// FieldChange is the old and new value of one field of a record.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// AuditEntry records one write to a car or an engine. Before is empty for
+// a create and After for a purge.
+type AuditEntry struct {
+	ID        int64                  `json:"id"`
+	Entity    string                 `json:"entity"`
+	EntityID  string                 `json:"entity_id"`
+	Operation string                 `json:"operation"`
+	Actor     string                 `json:"actor"`
+	ChangedAt time.Time              `json:"changed_at"`
+	Before    json.RawMessage        `json:"before,omitempty"`
+	After     json.RawMessage        `json:"after,omitempty"`
+	Changes   map[string]FieldChange `json:"changes,omitempty"`
+}
+
+// History is the audit trail of one record, oldest entry first.
+type History struct {
+	History []AuditEntry `json:"history"`
+}
+
+// StateAsOf returns the state a record had at the given time, taken from
+// the last entry written up to then. It reports false when the record did
+// not exist yet or was purged.
+func StateAsOf(entries []AuditEntry, asOf time.Time) (json.RawMessage, bool) {
+	var state json.RawMessage
+	for _, entry := range entries {
+		if entry.ChangedAt.After(asOf) {
+			break
+		}
+		state = entry.After
+	}
+	return state, len(state) > 0
+}
+
+// IsDeleted reports whether the record of a history is soft deleted or
+// purged, going by its last write.
+func IsDeleted(entries []AuditEntry) bool {
+	if len(entries) == 0 {
+		return false
+	}
+	operation := entries[len(entries)-1].Operation
+	return operation == OperationDelete || operation == OperationPurge
+}
+
+// CarState is a car as recorded in the audit log: its own columns, with
+// the engine referenced by id only.
+type CarState struct {
+	ID        uuid.UUID  `json:"id"`
+	Name      string     `json:"name"`
+	Year      string     `json:"year"`
+	Brand     string     `json:"brand"`
+	FuelType  string     `json:"fuel_type"`
+	EngineId  *uuid.UUID `json:"engine_id"`
+	Price     Money      `json:"price"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int64      `json:"version"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func NewCarState(car Car) CarState {
+	state := CarState{
+		ID:        car.ID,
+		Name:      car.Name,
+		Year:      car.Year,
+		Brand:     car.Brand,
+		FuelType:  car.FuelType,
+		Price:     car.Price,
+		CreatedAt: car.CreatedAt,
+		UpdatedAt: car.UpdatedAt,
+		Version:   car.Version,
+		DeletedAt: car.DeletedAt,
+	}
+	if car.Engine.EngineId != uuid.Nil {
+		engineId := car.Engine.EngineId
+		state.EngineId = &engineId
+	}
+	return state
+}
+
+// Car turns the state back into a car embedding the given engine.
+func (s CarState) Car(engine Engine) Car {
+	engine.CarCount = nil
+	engine.DeletedAt = nil
+	return Car{
+		ID:        s.ID,
+		Name:      s.Name,
+		Year:      s.Year,
+		Brand:     s.Brand,
+		FuelType:  s.FuelType,
+		Engine:    engine,
+		Price:     s.Price,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+		Version:   s.Version,
+		DeletedAt: s.DeletedAt,
+	}
+}
@@ -1,8 +1,10 @@
 package models
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,97 +17,146 @@ type Car struct {
 	Brand     string    `json:"brand"`
 	FuelType  string    `json:"fuel_type"`
 	Engine    Engine    `json:"engine"`
-	Price     float64   `json:"price"`
+	Price     Money     `json:"price"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"`
+
+	// DeletedAt is set on soft deleted cars
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// ConvertedPrice is the price in the currency a listing asked for
+	ConvertedPrice *Money `json:"converted_price,omitempty"`
+}
+
+// MarshalJSON writes the engine of a car whose engine was detached as null
+// rather than an engine with a nil id, like GraphQL does.
+func (c Car) MarshalJSON() ([]byte, error) {
+	// car has the fields of Car without its methods, so it is marshaled the
+	// usual way; the Engine below hides its engine field
+	type car Car
+	body := struct {
+		car
+		Engine *Engine `json:"engine"`
+	}{car: car(c)}
+	if c.Engine.EngineId != uuid.Nil {
+		body.Engine = &c.Engine
+	}
+	return json.Marshal(body)
 }
 
 type CarRequest struct {
-	Name     string  `json:"name"`
-	Year     string  `json:"year"`
-	Brand    string  `json:"brand"`
-	FuelType string  `json:"fuel_type"`
-	Engine   Engine  `json:"engine"`
-	Price    float64 `json:"price"`
+	Name     string `json:"name"`
+	Year     string `json:"year"`
+	Brand    string `json:"brand"`
+	FuelType string `json:"fuel_type"`
+	Engine   Engine `json:"engine"`
+	Price    Money  `json:"price"`
 }
 
-// Call all other validate functions
+// ValidateRequest runs every car validator and reports all the violations
+// at once, with the nested engine fields prefixed by "engine.".
 func ValidateRequest(carReq CarRequest) error {
-	if err := ValidateNameBrandPrice(carReq.Name, carReq.Brand, carReq.Price); err != nil {
-		return err
-	}
-	if err := ValidateYear(carReq.Year); err != nil {
-		return err
-	}
-	if err := ValidateEngine(carReq.Engine); err != nil {
-		return err
-	}
-	if err := ValidateFuelType(carReq.FuelType); err != nil {
-		return err
-	}
-	return nil
+	var v violations
+	v.merge("", ValidateNameBrandPrice(carReq.Name, carReq.Brand, carReq.Price))
+	v.merge("", ValidateYear(carReq.Year))
+	v.merge("engine", ValidateEngine(carReq.Engine))
+	v.merge("", ValidateFuelType(carReq.FuelType))
+	return v.err()
 }
 
+func ValidateNameBrandPrice(name string, brand string, price Money) error {
+	var v violations
 
-func ValidateNameBrandPrice(name string, brand string, price float64) error {
 	// validate name
 	if name == "" {
-		return errors.New("name is required")
+		v.add("name", CodeRequired, "name is required")
 	}
 
 	// validate brand
 	if brand == "" {
-		return errors.New("brand is required")
+		v.add("brand", CodeRequired, "brand is required")
 	}
 
 	// validate price
-	if price <= 0 {
-		return errors.New("enter valid price")
-	}
+	v.merge("", ValidatePrice("price", price))
 
-	return nil
+	return v.err()
 }
 
 func ValidateYear(year string) error {
+	var v violations
+
 	if year == "" {
-		return errors.New("year is required")
+		v.add("year", CodeRequired, "year is required")
+		return v.err()
 	}
 
 	yearInt, convErr := strconv.Atoi(year)
 	if convErr != nil {
-		return errors.New("year must be a valid number")
+		v.add("year", CodeNotANumber, "year must be a valid number")
+		return v.err()
 	}
 
 	currentYear := time.Now()
 	if yearInt < 1950 || yearInt > currentYear.Year() {
-		return errors.New("enter valid year")
+		v.add("year", CodeOutOfRange, fmt.Sprintf("year must be between 1950 and %d", currentYear.Year()))
 	}
-	return nil
+	return v.err()
 }
 
+// ValidFuelTypes lists the accepted values of fuel_type.
+var ValidFuelTypes = []string{"Petrol", "Electric", "Diesel", "Hybrid"}
+
 func ValidateFuelType(fuelType string) error {
+	var v violations
+
 	// validate fuelType
-	validateFuelTypes := []string{"Petrol", "Electric", "Diesel", "Hybrid"}
-	for _, validType := range validateFuelTypes {
+	if fuelType == "" {
+		v.add("fuel_type", CodeRequired, "fuel type is required")
+		return v.err()
+	}
+	for _, validType := range ValidFuelTypes {
 		if fuelType == validType {
 			return nil
 		}
 	}
-	return errors.New("enter a valid fuel type")
+	v.add("fuel_type", CodeInvalidChoice, "fuel type must be one of "+strings.Join(ValidFuelTypes, ", "))
+	return v.err()
 }
 
 func ValidateEngine(engine Engine) error {
+	var v violations
+
 	if engine.EngineId == uuid.Nil {
-		return errors.New("Engine Id is required")
+		v.add("engine_id", CodeRequired, "engine id is required")
 	}
 	if engine.Displacement <= 0 {
-		return errors.New("displacement must be greater than zero")
+		v.add("displacement", CodeMustBePositive, "displacement must be greater than zero")
 	}
 	if engine.NoOfCylinders <= 0 {
-		return errors.New("number of cylinders must be greater than zero")
+		v.add("no_of_cylinders", CodeMustBePositive, "number of cylinders must be greater than zero")
 	}
 	if engine.CarRange <= 0 {
-		return errors.New("car range must be greater than zero")
+		v.add("car_range", CodeMustBePositive, "car range must be greater than zero")
+	}
+	return v.err()
+}
+
+// ValidateEngineSpecs checks the specs a car request gives next to its
+// engine_id against the engine stored with that id, so a car is not
+// written on an engine other than the one the client meant.
+func ValidateEngineSpecs(requested Engine, engine Engine) error {
+	var v violations
+
+	if requested.Displacement != engine.Displacement {
+		v.add("engine.displacement", CodeMismatch, "displacement does not match the engine")
+	}
+	if requested.NoOfCylinders != engine.NoOfCylinders {
+		v.add("engine.no_of_cylinders", CodeMismatch, "number of cylinders does not match the engine")
+	}
+	if requested.CarRange != engine.CarRange {
+		v.add("engine.car_range", CodeMismatch, "car range does not match the engine")
 	}
-	return nil
+	return v.err()
 }
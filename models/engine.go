@@ -1,7 +1,7 @@
 package models
 
 import (
-	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +11,13 @@ type Engine struct {
 	Displacement  int64     `json:"displacement"`
 	NoOfCylinders int64     `json:"no_of_cylinders"`
 	CarRange      int64     `json:"car_range"`
+	Version       int64     `json:"version"`
+
+	// DeletedAt is set on soft deleted engines
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+
+	// CarCount is only filled when a listing asks for it
+	CarCount *int64 `json:"car_count,omitempty"`
 }
 
 type EngineRequest struct {
@@ -19,16 +26,40 @@ type EngineRequest struct {
 	CarRange      int64 `json:"car_range"`
 }
 
-
 func ValidateEngineRequest(engineRequest EngineRequest) error {
+	var v violations
+
 	if engineRequest.Displacement <= 0 {
-		return errors.New("invalid displacement")
+		v.add("displacement", CodeMustBePositive, "displacement must be greater than zero")
 	}
 	if engineRequest.NoOfCylinders <= 0 {
-		return errors.New("invalid number of cylinders")
+		v.add("no_of_cylinders", CodeMustBePositive, "number of cylinders must be greater than zero")
 	}
 	if engineRequest.CarRange <= 0 {
-		return errors.New("invalid number of cylinders")
+		v.add("car_range", CodeMustBePositive, "car range must be greater than zero")
+	}
+	return v.err()
+}
+
+// CascadeMode tells what happens to the cars of an engine being deleted.
+type CascadeMode string
+
+const (
+	// CascadeNone refuses to delete an engine that cars still use
+	CascadeNone CascadeMode = ""
+	// CascadeDetach keeps the cars but clears their engine
+	CascadeDetach CascadeMode = "detach"
+	// CascadeDelete deletes the cars together with the engine
+	CascadeDelete CascadeMode = "delete"
+)
+
+func ValidateCascadeMode(mode CascadeMode) error {
+	var v violations
+
+	switch mode {
+	case CascadeNone, CascadeDetach, CascadeDelete:
+	default:
+		v.add("cascade", CodeInvalidChoice, "cascade must be detach or delete")
 	}
-	return nil
+	return v.err()
 }
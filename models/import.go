@@ -0,0 +1,113 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/google/uuid"
+)
+
+// ImportRow is one car read from an import file, Line being where it
+// starts in the file. The engine of the car is the engine with
+// Car.Engine.EngineId when it is set, otherwise a live engine with the same
+// specs, which is created when there is none.
+type ImportRow struct {
+	Line int
+	Car  CarRequest
+
+	// Err is set when the row could not be read into a car
+	Err error
+}
+
+// ImportOptions tells how an import writes its rows.
+type ImportOptions struct {
+	// DryRun checks every row as if it was imported but writes nothing
+	DryRun bool
+	// Atomic writes every row or, when one of them fails, none at all
+	Atomic bool
+}
+
+// import row statuses
+const (
+	// ImportCreated rows were written
+	ImportCreated = "created"
+	// ImportValid rows would have been written, in a dry run
+	ImportValid = "valid"
+	// ImportFailed rows have Errors telling why they were not written
+	ImportFailed = "failed"
+	// ImportSkipped rows were fine but another row failed an atomic import
+	ImportSkipped = "skipped"
+)
+
+// ImportResult is what became of one row. The ids are only set for
+// records that exist after the import.
+type ImportResult struct {
+	Line          int                    `json:"line"`
+	Status        string                 `json:"status"`
+	CarID         *uuid.UUID             `json:"car_id,omitempty"`
+	EngineID      *uuid.UUID             `json:"engine_id,omitempty"`
+	EngineCreated bool                   `json:"engine_created,omitempty"`
+	Errors        []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// NewImportFailure reports a row that could not be imported because of err.
+func NewImportFailure(line int, err error) ImportResult {
+	var v violations
+	v.merge("", err)
+	for i := range v {
+		v[i].Pointer = apperrors.FieldPointer(v[i].Field)
+	}
+	return ImportResult{Line: line, Status: ImportFailed, Errors: v}
+}
+
+// ImportReport lists the result of every row in file order.
+type ImportReport struct {
+	DryRun  bool           `json:"dry_run"`
+	Atomic  bool           `json:"atomic"`
+	Total   int            `json:"total"`
+	Created int            `json:"created"`
+	Failed  int            `json:"failed"`
+	Rows    []ImportResult `json:"rows"`
+}
+
+// ValidateImportRow runs the car validators on an imported row, next to
+// the problems found while reading it. Unlike ValidateRequest the engine
+// may be given by its specs instead of its id.
+func ValidateImportRow(row ImportRow) error {
+	var read violations
+	read.merge("", row.Err)
+
+	// a row that could not be read at all has nothing to validate
+	for _, field := range read {
+		if field.Field == "" {
+			return read.err()
+		}
+	}
+
+	var v violations
+	v.merge("", ValidateNameBrandPrice(row.Car.Name, row.Car.Brand, row.Car.Price))
+	v.merge("", ValidateYear(row.Car.Year))
+	if row.Car.Engine.EngineId == uuid.Nil {
+		v.merge("engine", ValidateEngineRequest(EngineRequest{
+			Displacement:  row.Car.Engine.Displacement,
+			NoOfCylinders: row.Car.Engine.NoOfCylinders,
+			CarRange:      row.Car.Engine.CarRange,
+		}))
+	}
+	v.merge("", ValidateFuelType(row.Car.FuelType))
+
+	// a field that could not be read is only reported once
+	for _, field := range v {
+		reported := false
+		for _, readField := range read {
+			if readField.Field == field.Field || strings.HasPrefix(field.Field, readField.Field+".") {
+				reported = true
+				break
+			}
+		}
+		if !reported {
+			read = append(read, field)
+		}
+	}
+	return read.err()
+}
@@ -0,0 +1,248 @@
+package models
+
+import (
+	"strings"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// SortField is one key of a multi-field sort, e.g. "-price" is
+// SortField{Field: "price", Desc: true}.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort reads a comma separated sort spec like "brand,-price".
+func ParseSort(spec string) []SortField {
+	var fields []SortField
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "-") {
+			fields = append(fields, SortField{Field: strings.TrimPrefix(part, "-"), Desc: true})
+			continue
+		}
+		fields = append(fields, SortField{Field: strings.TrimPrefix(part, "+")})
+	}
+	return fields
+}
+
+// SortSpec is the inverse of ParseSort, used to tie a cursor to its sort.
+func SortSpec(fields []SortField) string {
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field.Desc {
+			parts = append(parts, "-"+field.Field)
+			continue
+		}
+		parts = append(parts, field.Field)
+	}
+	return strings.Join(parts, ",")
+}
+
+// CarSortFields lists the fields a car list can be sorted by.
+var CarSortFields = []string{
+	"name", "year", "brand", "fuel_type", "price", "created_at", "updated_at",
+	"displacement", "no_of_cylinders", "car_range",
+}
+
+// CarFilter narrows down and orders a car listing. Nil bounds are ignored.
+type CarFilter struct {
+	Brand    string
+	FuelType string
+
+	MinYear *int
+	MaxYear *int
+
+	// PriceCurrency keeps the cars priced in that currency, which is also
+	// the currency of the price bounds
+	PriceCurrency string
+	MinPrice      *Money
+	MaxPrice      *Money
+
+	MinDisplacement  *int64
+	MaxDisplacement  *int64
+	MinNoOfCylinders *int64
+	MaxNoOfCylinders *int64
+	MinCarRange      *int64
+	MaxCarRange      *int64
+
+	Sort   []SortField
+	Limit  int
+	Cursor string
+
+	// Currency asks for every price converted to that currency as well
+	Currency string
+}
+
+// CarPage is one page of a car listing. NextCursor is empty on the last page.
+type CarPage struct {
+	Cars       []Car  `json:"cars"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ValidateCarFilter checks the sort fields and page size, filling in the
+// defaults (newest first, DefaultPageSize) when they are missing.
+func ValidateCarFilter(filter *CarFilter) error {
+	var v violations
+
+	if len(filter.Sort) == 0 {
+		filter.Sort = []SortField{{Field: "created_at", Desc: true}}
+	}
+	for _, sort := range filter.Sort {
+		if !contains(CarSortFields, sort.Field) {
+			v.add("sort", CodeInvalidChoice, "cannot sort by "+sort.Field+", use one of "+strings.Join(CarSortFields, ", "))
+		}
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > MaxPageSize {
+		v.add("limit", CodeOutOfRange, "limit must be between 1 and 100")
+	}
+
+	if filter.FuelType != "" {
+		v.merge("", ValidateFuelType(filter.FuelType))
+	}
+
+	// price bounds only compare prices in their own currency
+	for _, bound := range []*Money{filter.MinPrice, filter.MaxPrice} {
+		if bound == nil {
+			continue
+		}
+		if filter.PriceCurrency == "" {
+			filter.PriceCurrency = bound.Currency
+		}
+		if bound.Currency != filter.PriceCurrency {
+			v.add("price_currency", CodeInvalid, "price bounds must be in the price currency")
+		}
+	}
+	// amounts in different currencies do not compare, so prices are only
+	// sorted among the cars priced in one currency
+	if filter.PriceCurrency == "" && sortsBy(filter.Sort, "price") {
+		v.add("sort", CodeInvalid, "sorting by price needs price_currency, prices in different currencies do not compare")
+	}
+	if filter.PriceCurrency != "" && !IsCurrency(filter.PriceCurrency) {
+		v.add("price_currency", CodeInvalidCurrency, "currency must be an ISO 4217 code like USD")
+	}
+	if filter.Currency != "" && !IsCurrency(filter.Currency) {
+		v.add("currency", CodeInvalidCurrency, "currency must be an ISO 4217 code like USD")
+	}
+
+	return v.err()
+}
+
+func sortsBy(sort []SortField, field string) bool {
+	for _, sortField := range sort {
+		if sortField.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CarSortValue returns the value of one of the CarSortFields of car.
+func CarSortValue(car Car, field string) any {
+	switch field {
+	case "name":
+		return car.Name
+	case "year":
+		return car.Year
+	case "brand":
+		return car.Brand
+	case "fuel_type":
+		return car.FuelType
+	case "price":
+		return car.Price.AmountMinor
+	case "created_at":
+		return car.CreatedAt
+	case "updated_at":
+		return car.UpdatedAt
+	case "displacement":
+		return car.Engine.Displacement
+	case "no_of_cylinders":
+		return car.Engine.NoOfCylinders
+	case "car_range":
+		return car.Engine.CarRange
+	}
+	return nil
+}
+
+// EngineSortFields lists the fields an engine list can be sorted by.
+var EngineSortFields = []string{"displacement", "no_of_cylinders", "car_range"}
+
+// EngineFilter narrows down and orders an engine listing.
+type EngineFilter struct {
+	MinDisplacement  *int64
+	MaxDisplacement  *int64
+	MinNoOfCylinders *int64
+	MaxNoOfCylinders *int64
+	MinCarRange      *int64
+	MaxCarRange      *int64
+
+	// IncludeCarCount adds how many cars use each engine
+	IncludeCarCount bool
+
+	Sort   []SortField
+	Limit  int
+	Cursor string
+}
+
+// EnginePage is one page of an engine listing.
+type EnginePage struct {
+	Engines    []Engine `json:"engines"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// ValidateEngineFilter checks the sort fields and page size, filling in
+// the defaults (smallest displacement first, DefaultPageSize).
+func ValidateEngineFilter(filter *EngineFilter) error {
+	var v violations
+
+	if len(filter.Sort) == 0 {
+		filter.Sort = []SortField{{Field: "displacement"}}
+	}
+	for _, sort := range filter.Sort {
+		if !contains(EngineSortFields, sort.Field) {
+			v.add("sort", CodeInvalidChoice, "cannot sort by "+sort.Field+", use one of "+strings.Join(EngineSortFields, ", "))
+		}
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > MaxPageSize {
+		v.add("limit", CodeOutOfRange, "limit must be between 1 and 100")
+	}
+
+	return v.err()
+}
+
+// EngineSortValue returns the value of one of the EngineSortFields of engine.
+func EngineSortValue(engine Engine, field string) any {
+	switch field {
+	case "displacement":
+		return engine.Displacement
+	case "no_of_cylinders":
+		return engine.NoOfCylinders
+	case "car_range":
+		return engine.CarRange
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency given to the prices stored before cars
+// had one, and the currency of price filters that do not name another.
+const DefaultCurrency = "USD"
+
+// CodeInvalidCurrency marks a currency that is not an ISO 4217 code.
+const CodeInvalidCurrency = "invalid_currency"
+
+// Money is an exact amount in the minor unit of its ISO 4217 currency,
+// e.g. {2500000, "USD"} is 25000.00 US dollars and {25000, "JPY"} is
+// 25000 yen. Amounts are never floats, so sums and comparisons are exact.
+type Money struct {
+	AmountMinor int64  `json:"amount_minor"`
+	Currency    string `json:"currency"`
+}
+
+// Decimal formats the amount in major units, e.g. "25000.00".
+func (m Money) Decimal() string {
+	digits := MinorDigits(m.Currency)
+	amount := m.AmountMinor
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	text := strconv.FormatInt(amount, 10)
+	if digits == 0 {
+		return sign + text
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// ParseMoney reads a decimal amount in major units of currency, e.g.
+// ParseMoney("25000.5", "USD") is {2500050, "USD"}. An amount with more
+// decimals than the currency has is rejected instead of rounded.
+func ParseMoney(amount string, currency string) (Money, error) {
+	if !IsCurrency(currency) {
+		return Money{}, errors.New("unknown currency " + currency)
+	}
+
+	whole, fraction, _ := strings.Cut(amount, ".")
+	if !isDigits(strings.TrimPrefix(whole, "-")) || (fraction != "" && !isDigits(fraction)) {
+		return Money{}, errors.New("invalid amount " + amount)
+	}
+
+	digits := MinorDigits(currency)
+	if len(fraction) > digits {
+		return Money{}, errors.New(currency + " amounts have at most " + strconv.Itoa(digits) + " decimals")
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, errors.New("invalid amount " + amount)
+	}
+	return Money{AmountMinor: minor, Currency: currency}, nil
+}
+
+func isDigits(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePrice checks that a price is positive and in a known currency.
+func ValidatePrice(field string, price Money) error {
+	var v violations
+
+	if price.Currency == "" {
+		v.add(field+".currency", CodeRequired, "currency is required")
+	} else if !IsCurrency(price.Currency) {
+		v.add(field+".currency", CodeInvalidCurrency, "currency must be an ISO 4217 code like USD")
+	}
+	if price.AmountMinor <= 0 {
+		v.add(field+".amount_minor", CodeMustBePositive, "price must be greater than zero")
+	}
+
+	return v.err()
+}
+
+// IsCurrency reports whether code is an active ISO 4217 currency code.
+func IsCurrency(code string) bool {
+	_, ok := currencyDigits[code]
+	return ok
+}
+
+// MinorDigits returns how many decimals the currency has, i.e. the
+// exponent of its minor unit.
+func MinorDigits(code string) int {
+	return currencyDigits[code]
+}
+
+// currencyDigits maps every active ISO 4217 code to its minor unit
+// exponent. Most currencies have cents, so only the others are spelled out
+// in the list below.
+var currencyDigits = func() map[string]int {
+	digits := make(map[string]int)
+	for _, code := range strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV
+		BRL BSD BTN BWP BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK
+		DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GTQ GYD HKD HNL
+		HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL
+		MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO
+		NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK
+		SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS
+		UAH USD USN UYU UZS VED VES WST XCD YER ZAR ZMW ZWG
+	`) {
+		digits[code] = 2
+	}
+	for _, code := range strings.Fields(`BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF`) {
+		digits[code] = 0
+	}
+	for _, code := range strings.Fields(`BHD IQD JOD KWD LYD OMR TND`) {
+		digits[code] = 3
+	}
+	for _, code := range strings.Fields(`CLF UYW`) {
+		digits[code] = 4
+	}
+	return digits
+}()
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// CodeReadOnly marks a field that a request is not allowed to change.
+const CodeReadOnly = "read_only"
+
+// CarPatch holds the car columns a partial update changes. Nil fields are
+// left as they are.
+type CarPatch struct {
+	Name     *string
+	Year     *string
+	Brand    *string
+	FuelType *string
+	EngineId *uuid.UUID
+	Price    *Money
+}
+
+func (p CarPatch) IsEmpty() bool {
+	return p.Name == nil && p.Year == nil && p.Brand == nil && p.FuelType == nil && p.EngineId == nil && p.Price == nil
+}
+
+// NewCarRequest returns the request that would recreate car as it is.
+func NewCarRequest(car Car) CarRequest {
+	return CarRequest{
+		Name:     car.Name,
+		Year:     car.Year,
+		Brand:    car.Brand,
+		FuelType: car.FuelType,
+		Engine: Engine{
+			EngineId:      car.Engine.EngineId,
+			Displacement:  car.Engine.Displacement,
+			NoOfCylinders: car.Engine.NoOfCylinders,
+			CarRange:      car.Engine.CarRange,
+		},
+		Price: car.Price,
+	}
+}
+
+// DiffCarRequest returns the columns that differ between the current and
+// the patched car. The engine specs belong to the engine, so a car patch
+// may only point the car at another engine_id.
+func DiffCarRequest(current CarRequest, patched CarRequest) (CarPatch, error) {
+	var v violations
+	var patch CarPatch
+
+	if patched.Name != current.Name {
+		patch.Name = &patched.Name
+	}
+	if patched.Year != current.Year {
+		patch.Year = &patched.Year
+	}
+	if patched.Brand != current.Brand {
+		patch.Brand = &patched.Brand
+	}
+	if patched.FuelType != current.FuelType {
+		patch.FuelType = &patched.FuelType
+	}
+	if patched.Price != current.Price {
+		patch.Price = &patched.Price
+	}
+
+	if patched.Engine.EngineId != current.Engine.EngineId {
+		patch.EngineId = &patched.Engine.EngineId
+	} else {
+		if patched.Engine.Displacement != current.Engine.Displacement {
+			v.add("engine.displacement", CodeReadOnly, "engine specs are changed through the engine itself")
+		}
+		if patched.Engine.NoOfCylinders != current.Engine.NoOfCylinders {
+			v.add("engine.no_of_cylinders", CodeReadOnly, "engine specs are changed through the engine itself")
+		}
+		if patched.Engine.CarRange != current.Engine.CarRange {
+			v.add("engine.car_range", CodeReadOnly, "engine specs are changed through the engine itself")
+		}
+	}
+
+	return patch, v.err()
+}
+
+// ValidateCarPatch checks the car that results from a partial update. The
+// engine specs of a newly referenced engine are looked up by the store, so
+// only its id is validated, and only when the patch changes it: a car whose
+// engine was detached can still be patched without getting a new one.
+func ValidateCarPatch(current CarRequest, patched CarRequest) error {
+	var v violations
+	v.merge("", ValidateNameBrandPrice(patched.Name, patched.Brand, patched.Price))
+	v.merge("", ValidateYear(patched.Year))
+	if patched.Engine.EngineId != current.Engine.EngineId && patched.Engine.EngineId == uuid.Nil {
+		v.add("engine.engine_id", CodeRequired, "engine id is required")
+	}
+	v.merge("", ValidateFuelType(patched.FuelType))
+	return v.err()
+}
+
+// EnginePatch holds the engine columns a partial update changes.
+type EnginePatch struct {
+	Displacement  *int64
+	NoOfCylinders *int64
+	CarRange      *int64
+}
+
+func (p EnginePatch) IsEmpty() bool {
+	return p.Displacement == nil && p.NoOfCylinders == nil && p.CarRange == nil
+}
+
+// NewEngineRequest returns the request that would recreate engine as it is.
+func NewEngineRequest(engine Engine) EngineRequest {
+	return EngineRequest{
+		Displacement:  engine.Displacement,
+		NoOfCylinders: engine.NoOfCylinders,
+		CarRange:      engine.CarRange,
+	}
+}
+
+// DiffEngineRequest returns the columns that differ between the current
+// and the patched engine.
+func DiffEngineRequest(current EngineRequest, patched EngineRequest) EnginePatch {
+	var patch EnginePatch
+
+	if patched.Displacement != current.Displacement {
+		patch.Displacement = &patched.Displacement
+	}
+	if patched.NoOfCylinders != current.NoOfCylinders {
+		patch.NoOfCylinders = &patched.NoOfCylinders
+	}
+	if patched.CarRange != current.CarRange {
+		patch.CarRange = &patched.CarRange
+	}
+
+	return patch
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateCarPatchEngine(t *testing.T) {
+	engineId := uuid.New()
+	car := CarRequest{
+		Name:     "Civic",
+		Year:     "2020",
+		Brand:    "Honda",
+		FuelType: "Petrol",
+		Engine:   Engine{EngineId: engineId, Displacement: 2000, NoOfCylinders: 4, CarRange: 600},
+		Price:    Money{AmountMinor: 2500000, Currency: "USD"},
+	}
+	detached := car
+	detached.Engine = Engine{}
+
+	tests := []struct {
+		name    string
+		current CarRequest
+		patched func(CarRequest) CarRequest
+		wantErr bool
+	}{
+		{
+			name:    "rename a car with an engine",
+			current: car,
+			patched: func(c CarRequest) CarRequest { c.Name = "Z"; return c },
+		},
+		{
+			name:    "rename a detached car",
+			current: detached,
+			patched: func(c CarRequest) CarRequest { c.Name = "Z"; return c },
+		},
+		{
+			name:    "attach an engine to a detached car",
+			current: detached,
+			patched: func(c CarRequest) CarRequest { c.Engine.EngineId = engineId; return c },
+		},
+		{
+			name:    "clear the engine id",
+			current: car,
+			patched: func(c CarRequest) CarRequest { c.Engine.EngineId = uuid.Nil; return c },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCarPatch(tt.current, tt.patched(tt.current))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCarPatch() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricePoint is a price a car had from EffectiveFrom until EffectiveTo.
+// The current price has no EffectiveTo.
+type PricePoint struct {
+	Price         Money      `json:"price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+}
+
+// PriceTimeline lists the prices of a car, oldest first.
+type PriceTimeline struct {
+	CarID  uuid.UUID    `json:"car_id"`
+	Prices []PricePoint `json:"prices"`
+}
+
+// PriceGroupFields lists what the price statistics can be grouped by.
+var PriceGroupFields = []string{"brand", "fuel_type", "year"}
+
+// PriceStats summarises the current prices of the live cars in one group
+// priced in one currency. Average is rounded to the minor unit.
+type PriceStats struct {
+	Group    string `json:"group"`
+	Currency string `json:"currency"`
+	Count    int64  `json:"count"`
+	Average  Money  `json:"average"`
+	Min      Money  `json:"min"`
+	Max      Money  `json:"max"`
+}
+
+// PriceReport is the price statistics of every group, ordered by group
+// and currency. Prices in different currencies are never mixed.
+type PriceReport struct {
+	GroupBy string       `json:"group_by"`
+	Stats   []PriceStats `json:"stats"`
+}
+
+func ValidatePriceGroupBy(groupBy string) error {
+	var v violations
+
+	if !contains(PriceGroupFields, groupBy) {
+		v.add("group_by", CodeInvalidChoice, "cannot group by "+groupBy+", use one of "+strings.Join(PriceGroupFields, ", "))
+	}
+	return v.err()
+}
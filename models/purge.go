@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// PurgeResult reports what a purge of soft deleted records removed.
+type PurgeResult struct {
+	Before  time.Time `json:"before"`
+	Cars    int64     `json:"cars"`
+	Engines int64     `json:"engines"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// violation codes shared by the validators
+const (
+	CodeRequired       = "required"
+	CodeInvalid        = "invalid"
+	CodeNotANumber     = "not_a_number"
+	CodeOutOfRange     = "out_of_range"
+	CodeMustBePositive = "must_be_positive"
+	CodeInvalidChoice  = "invalid_choice"
+	CodeMismatch       = "mismatch"
+)
+
+// violations collects every problem found while validating a request
+// instead of stopping at the first one.
+type violations []apperrors.FieldError
+
+func (v *violations) add(field string, code string, message string) {
+	*v = append(*v, apperrors.FieldError{Field: field, Code: code, Message: message})
+}
+
+// merge adds the field errors carried by err, prefixing their field path.
+func (v *violations) merge(prefix string, err error) {
+	var validationErr *apperrors.ValidationError
+	if !errors.As(err, &validationErr) {
+		if err != nil {
+			v.add(prefix, CodeInvalid, err.Error())
+		}
+		return
+	}
+
+	for _, field := range validationErr.Fields {
+		if prefix != "" {
+			field.Field = prefix + "." + field.Field
+		}
+		*v = append(*v, field)
+	}
+}
+
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return apperrors.NewFieldValidation(v)
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ETag of a car covers the engine embedded in it, so changing either one
+// invalidates copies cached by clients.
+func (c Car) ETag() string {
+	return fmt.Sprintf(`"%d.%d"`, c.Version, c.Engine.Version)
+}
+
+func (e Engine) ETag() string {
+	return fmt.Sprintf(`"%d"`, e.Version)
+}
+
+// ETags is the list of entity tags of an If-Match or If-None-Match header.
+// "*" matches any current record.
+type ETags []string
+
+// ParseETags splits a header value like `"1.2", W/"3"` into its tags.
+func ParseETags(header string) ETags {
+	var tags ETags
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Match compares etag with the tags using the strong comparison of If-Match:
+// weak tags never match.
+func (t ETags) Match(etag string) bool {
+	for _, tag := range t {
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchWeak compares etag with the tags using the weak comparison of
+// If-None-Match, ignoring the W/ prefix.
+func (t ETags) MatchWeak(etag string) bool {
+	for _, tag := range t {
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
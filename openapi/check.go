@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+)
+
+// Checker serves requests with the real handlers and compares every
+// response with what the document says about it, so the document cannot
+// quietly drift away from the handlers.
+type Checker struct {
+	doc     *Document
+	handler http.Handler
+	prefix  string
+	covered map[string]bool
+}
+
+func NewChecker(doc *Document, handler http.Handler) *Checker {
+	return &Checker{doc: doc, handler: handler, prefix: doc.prefix(), covered: map[string]bool{}}
+}
+
+// Do serves r and returns the response with every way it differs from
+// the documented operation.
+func (c *Checker) Do(r *http.Request) (*httptest.ResponseRecorder, []string) {
+	recorder := httptest.NewRecorder()
+	c.handler.ServeHTTP(recorder, r)
+
+	name := r.Method + " " + r.URL.Path
+	path, ok := strings.CutPrefix(r.URL.Path, c.prefix)
+	if !ok {
+		return recorder, []string{name + ": not under " + c.prefix}
+	}
+
+	operation, template := c.doc.Operation(r.Method, path)
+	if operation == nil {
+		return recorder, []string{name + ": operation is not documented"}
+	}
+	c.covered[r.Method+" "+template] = true
+	name = r.Method + " " + template
+
+	var drift []string
+	for _, problem := range c.compare(operation, recorder) {
+		drift = append(drift, fmt.Sprintf("%s answered %d: %s", name, recorder.Code, problem))
+	}
+	return recorder, drift
+}
+
+func (c *Checker) compare(operation *Operation, recorder *httptest.ResponseRecorder) []string {
+	response, ok := operation.Responses[strconv.Itoa(recorder.Code)]
+	if !ok {
+		if response, ok = operation.Responses["default"]; !ok {
+			return []string{"status is not documented"}
+		}
+	}
+
+	var problems []string
+	for name, header := range response.Headers {
+		if header.Required && recorder.Header().Get(name) == "" {
+			problems = append(problems, "header "+name+" is missing")
+		}
+	}
+
+	body := recorder.Body.Bytes()
+	if len(response.Content) == 0 {
+		if len(body) > 0 {
+			problems = append(problems, "body is not documented")
+		}
+		return problems
+	}
+
+	mediaType, _, err := mime.ParseMediaType(recorder.Header().Get("Content-Type"))
+	if err != nil {
+		return append(problems, "Content-Type is missing or invalid")
+	}
+	media, ok := response.Content[mediaType]
+	if !ok {
+		return append(problems, "Content-Type "+mediaType+" is not documented")
+	}
+	if media.Schema == nil {
+		return problems
+	}
+
+	switch {
+	case mediaType == "application/x-ndjson":
+		scanner := bufio.NewScanner(bytes.NewReader(body))
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			problems = append(problems, c.validate(media.Schema, scanner.Bytes(), fmt.Sprintf("line %d ", line))...)
+		}
+	case isJSON(mediaType):
+		problems = append(problems, c.validate(media.Schema, body, "")...)
+	}
+	return problems
+}
+
+func (c *Checker) validate(schema *Schema, body []byte, where string) []string {
+	value, err := Decode(body)
+	if err != nil {
+		return []string{where + "body is not json: " + err.Error()}
+	}
+
+	problems := c.doc.Validate(schema, value, true)
+	for i := range problems {
+		problems[i] = where + "body " + problems[i]
+	}
+	return problems
+}
+
+// Uncovered lists the documented operations no request went to.
+func (c *Checker) Uncovered() []string {
+	var uncovered []string
+	for _, operation := range c.doc.Operations() {
+		if !c.covered[operation] {
+			uncovered = append(uncovered, operation)
+		}
+	}
+	return uncovered
+}
@@ -0,0 +1,363 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// Version is the OpenAPI version the documents are written in.
+const Version = "3.1.0"
+
+// ProblemType is the media type of error responses.
+const ProblemType = "application/problem+json"
+
+// Document is an OpenAPI document, with just the parts the api uses.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Security   []map[string][]string `json:"security,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of one path by lower case method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+
+	// Permission is the permission the caller needs, see auth.Permission
+	Permission string `json:"x-permission,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType has no schema for bodies that are not json, like csv.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+}
+
+// Endpoint is one route of the api: the handler it is served by and what
+// the document says about it. The router and the document are both built
+// from the same list of endpoints.
+type Endpoint struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+
+	ID          string
+	Summary     string
+	Description string
+	Tag         string
+	Permission  string
+	Params      []Param
+	Body        []Content
+	Responses   []Reply
+}
+
+// Param is a path, query or header parameter. Example is a value of the
+// parameter's type, a string when nil. Requests are checked against it,
+// see RequestValidator.
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Example     any
+	Enum        []string
+}
+
+// Content is a body in one media type. Model is a value of the type sent,
+// nil for bodies that are not json.
+type Content struct {
+	MediaType string
+	Model     any
+	Schema    *Schema
+}
+
+// Reply is one documented response. Headers lists the headers always set.
+type Reply struct {
+	Status      int
+	Description string
+	Content     []Content
+	Headers     []string
+}
+
+func PathParam(name string, example any, description string) Param {
+	return Param{Name: name, In: "path", Description: description, Example: example}
+}
+
+func Query(name string, example any, description string) Param {
+	return Param{Name: name, In: "query", Description: description, Example: example}
+}
+
+func HeaderParam(name string, description string) Param {
+	return Param{Name: name, In: "header", Description: description}
+}
+
+// JSON is an application/json body of the type of model.
+func JSON(model any) Content {
+	return Content{MediaType: "application/json", Model: model}
+}
+
+// Body is a body in the media type, described by schema, which may be nil.
+func Body(mediaType string, schema *Schema) Content {
+	return Content{MediaType: mediaType, Schema: schema}
+}
+
+func OK(description string, content ...Content) Reply {
+	return Reply{Status: http.StatusOK, Description: description, Content: content}
+}
+
+// Problems documents error responses, which all have a problem body.
+func Problems(statuses ...int) []Reply {
+	replies := make([]Reply, len(statuses))
+	for i, status := range statuses {
+		replies[i] = Reply{
+			Status:      status,
+			Description: http.StatusText(status),
+			Content:     []Content{{MediaType: ProblemType, Model: apperrors.Problem{}}},
+		}
+	}
+	return replies
+}
+
+// Build writes the document of the endpoints, which are served under
+// prefix. Endpoints with a permission require authentication and can
+// answer 401 and 403 besides their own responses, those with parameters
+// or a body 400 when a request does not match them, and those with a body
+// 413 when it is too large.
+func Build(info Info, prefix string, endpoints []Endpoint, g *Generator) *Document {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: prefix}},
+		Security: []map[string][]string{
+			{"bearerAuth": {}},
+			{"apiKey": {}},
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "an HS256 or RS256 token with sub, exp and roles claims"},
+				"apiKey":     {Type: "apiKey", In: "header", Name: "X-API-Key", Description: "a key made with `apikey create`"},
+			},
+		},
+	}
+
+	for _, endpoint := range endpoints {
+		operation := &Operation{
+			OperationID: endpoint.ID,
+			Summary:     endpoint.Summary,
+			Description: endpoint.Description,
+			Permission:  endpoint.Permission,
+			Responses:   map[string]*Response{},
+		}
+		if endpoint.Tag != "" {
+			operation.Tags = []string{endpoint.Tag}
+		}
+
+		for _, param := range endpoint.Params {
+			operation.Parameters = append(operation.Parameters, param.parameter(g))
+		}
+
+		if len(endpoint.Body) > 0 {
+			operation.RequestBody = &RequestBody{Required: true, Content: content(endpoint.Body, g)}
+		}
+
+		replies := append([]Reply{}, endpoint.Responses...)
+		if len(endpoint.Params) > 0 || len(endpoint.Body) > 0 {
+			replies = withProblem(replies, http.StatusBadRequest)
+		}
+		if len(endpoint.Body) > 0 {
+			replies = withProblem(replies, http.StatusRequestEntityTooLarge)
+		}
+		if endpoint.Permission != "" {
+			replies = append(replies, Problems(http.StatusUnauthorized, http.StatusForbidden)...)
+		}
+		replies = append(replies, Problems(http.StatusInternalServerError)...)
+		for _, reply := range replies {
+			operation.Responses[strconv.Itoa(reply.Status)] = reply.response(g)
+		}
+
+		item, ok := doc.Paths[endpoint.Path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[endpoint.Path] = item
+		}
+		item[strings.ToLower(endpoint.Method)] = operation
+	}
+
+	doc.Components.Schemas = g.Schemas()
+	return doc
+}
+
+// withProblem adds the error response unless the endpoint lists it.
+func withProblem(replies []Reply, status int) []Reply {
+	for _, reply := range replies {
+		if reply.Status == status {
+			return replies
+		}
+	}
+	return append(replies, Problems(status)...)
+}
+
+func (p Param) parameter(g *Generator) Parameter {
+	schema := &Schema{Type: "string"}
+	if p.Example != nil {
+		schema = g.Schema(p.Example)
+	}
+	if len(p.Enum) > 0 {
+		copied := *schema
+		for _, value := range p.Enum {
+			copied.Enum = append(copied.Enum, value)
+		}
+		schema = &copied
+	}
+	return Parameter{Name: p.Name, In: p.In, Description: p.Description, Required: p.In == "path", Schema: schema}
+}
+
+func (r Reply) response(g *Generator) *Response {
+	response := &Response{Description: r.Description}
+	if len(r.Content) > 0 {
+		response.Content = content(r.Content, g)
+	}
+	for _, name := range r.Headers {
+		if response.Headers == nil {
+			response.Headers = map[string]Header{}
+		}
+		response.Headers[name] = Header{Required: true, Schema: &Schema{Type: "string"}}
+	}
+	return response
+}
+
+func content(contents []Content, g *Generator) map[string]MediaType {
+	media := map[string]MediaType{}
+	for _, c := range contents {
+		schema := c.Schema
+		if schema == nil && c.Model != nil {
+			schema = g.Schema(c.Model)
+		}
+		media[c.MediaType] = MediaType{Schema: schema}
+	}
+	return media
+}
+
+// prefix is the path the operations are served under.
+func (d *Document) prefix() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(d.Servers[0].URL, "/")
+}
+
+// Operations lists every operation as "METHOD /path", sorted.
+func (d *Document) Operations() []string {
+	var operations []string
+	for path, item := range d.Paths {
+		for method := range item {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(operations)
+	return operations
+}
+
+// Operation finds the operation of a request path, without the server
+// prefix, returning the path template it matched too. Literal segments
+// win over parameters, so /cars/brand is not read as /cars/{id}.
+func (d *Document) Operation(method string, path string) (*Operation, string) {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+
+	bestTemplate := ""
+	bestLiterals := -1
+	for template := range d.Paths {
+		literals, ok := matchTemplate(template, segments)
+		if ok && literals > bestLiterals {
+			bestTemplate, bestLiterals = template, literals
+		}
+	}
+	if bestTemplate == "" {
+		return nil, ""
+	}
+	return d.Paths[bestTemplate][strings.ToLower(method)], bestTemplate
+}
+
+func matchTemplate(template string, segments []string) (int, bool) {
+	parts := strings.Split(strings.Trim(template, "/"), "/")
+	if len(parts) != len(segments) {
+		return 0, false
+	}
+
+	literals := 0
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if part != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+)
+
+//go:embed viewer.html
+var viewerPage string
+
+// JSONHandler serves the document. It is written once, the document does
+// not change while the server runs.
+func JSONHandler(doc *Document) http.Handler {
+	body, err := json.MarshalIndent(doc, "", "  ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			w.WriteHeader(500)
+			log.Println("Error marshaling the openapi document: ", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		w.Write(body)
+	})
+}
+
+// ViewerHandler serves a page that lists the operations of the document
+// at specURL and lets them be tried out from the browser.
+func ViewerHandler(title string, specURL string) http.Handler {
+	page := strings.NewReplacer(
+		"{{title}}", html.EscapeString(title),
+		"{{spec}}", html.EscapeString(specURL),
+	).Replace(viewerPage)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(200)
+		w.Write([]byte(page))
+	})
+}
@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// RequestValidator checks every request against its operation in the
+// document before the handler sees it: path and query parameters against
+// their schemas, and json bodies strictly, so unknown properties and
+// values of the wrong type are refused with a 400 that points at each of
+// them. Json bodies may be up to maxJSON bytes long, other bodies, the
+// imported files, are cut off after maxFile bytes.
+func RequestValidator(doc *Document, maxJSON int64, maxFile int64) func(http.Handler) http.Handler {
+	prefix := doc.prefix()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, ok := strings.CutPrefix(r.URL.Path, prefix)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			operation, template := doc.Operation(r.Method, path)
+			if operation == nil {
+				// not a route of the document, the router answers it
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if violations := doc.checkParams(operation, template, path, r.URL.Query()); len(violations) > 0 {
+				err := apperrors.NewSchemaViolation(violations)
+				log.Println("Error validating the request: ", err)
+				apperrors.WriteHTTP(w, err)
+				return
+			}
+
+			if operation.RequestBody != nil {
+				if err := doc.checkBody(operation.RequestBody, w, r, maxJSON, maxFile); err != nil {
+					log.Println("Error validating the request: ", err)
+					apperrors.WriteHTTP(w, err)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// checkParams checks the path parameters and the query parameters that
+// are set. Header parameters are all plain strings.
+func (d *Document) checkParams(operation *Operation, template string, path string, query url.Values) []apperrors.FieldError {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	positions := map[string]int{}
+	for i, part := range strings.Split(strings.Trim(template, "/"), "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			positions[part[1:len(part)-1]] = i
+		}
+	}
+
+	var violations []apperrors.FieldError
+	for _, param := range operation.Parameters {
+		var values []string
+		switch param.In {
+		case "path":
+			if i, ok := positions[param.Name]; ok && i < len(segments) {
+				if value, err := url.PathUnescape(segments[i]); err == nil {
+					values = []string{value}
+				}
+			}
+		case "query":
+			values = query[param.Name]
+		}
+
+		for _, raw := range values {
+			// an empty query parameter is read as not set
+			if raw == "" && param.In == "query" {
+				continue
+			}
+			violations = append(violations, d.checkParam(param, raw)...)
+		}
+	}
+	return violations
+}
+
+// checkParam reads a raw parameter as the json value of its schema type
+// and validates it.
+func (d *Document) checkParam(param Parameter, raw string) []apperrors.FieldError {
+	fail := func(format string) []apperrors.FieldError {
+		return []apperrors.FieldError{{Field: param.Name, Code: CodeInvalidType, Message: strconv.Quote(raw) + " " + format}}
+	}
+
+	var value any = raw
+	schema := param.Schema
+	if types := typesOf(schema); len(types) > 0 {
+		switch {
+		case slices.Contains(types, "integer"):
+			if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+				return fail("is not an integer")
+			}
+			value = json.Number(raw)
+		case slices.Contains(types, "number"):
+			if _, err := strconv.ParseFloat(raw, 64); err != nil {
+				return fail("is not a number")
+			}
+			value = json.Number(raw)
+		case slices.Contains(types, "boolean"):
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fail("is not true or false")
+			}
+			value = parsed
+		}
+	}
+
+	violations := d.Check(schema, value, false)
+	for i := range violations {
+		violations[i].Field = param.Name
+		violations[i].Pointer = ""
+	}
+	return violations
+}
+
+// checkBody validates a json body, leaving it readable for the handler,
+// and limits the size of other bodies, which the handlers read as they
+// go. A body without a Content-Type, or sent to an operation that only
+// takes json, is read as json, as the handlers always have.
+func (d *Document) checkBody(body *RequestBody, w http.ResponseWriter, r *http.Request, maxJSON int64, maxFile int64) error {
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	media, ok := body.Content[mediaType]
+	if !ok {
+		media, ok = body.Content["application/json"]
+		if !ok || (mediaType != "" && len(body.Content) > 1) {
+			accepted := make([]string, 0, len(body.Content))
+			for documented := range body.Content {
+				accepted = append(accepted, documented)
+			}
+			sort.Strings(accepted)
+			return apperrors.NewUnsupportedMediaType(contentType, accepted...)
+		}
+		mediaType = "application/json"
+	}
+
+	if !isJSON(mediaType) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxFile)
+		return nil
+	}
+
+	raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxJSON))
+	if err != nil {
+		return apperrors.NewBadRequest("request body cannot be read", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(raw))
+
+	value, err := Decode(raw)
+	if err != nil {
+		return apperrors.NewBadRequest("request body is not valid json", err)
+	}
+	if violations := d.Check(media.Schema, value, true); len(violations) > 0 {
+		return apperrors.NewSchemaViolation(violations)
+	}
+	return nil
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,240 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/google/uuid"
+)
+
+// the codes of the violations Check reports
+const (
+	CodeRequired      = "required"
+	CodeInvalidType   = "invalid_type"
+	CodeInvalidChoice = "invalid_choice"
+	CodeInvalidFormat = "invalid_format"
+	CodeOutOfRange    = "out_of_range"
+	CodeUnknownField  = "unknown_field"
+)
+
+// Validate checks a json value, decoded with UseNumber, against a schema
+// of the document. Each problem found is reported with the json pointer
+// of the value. In strict mode objects may not have properties their
+// schema does not list.
+func (d *Document) Validate(schema *Schema, value any, strict bool) []string {
+	var problems []string
+	for _, violation := range d.Check(schema, value, strict) {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		problems = append(problems, pointer+": "+violation.Message)
+	}
+	return problems
+}
+
+// Check is Validate with the violations as field errors, the pointer of
+// each set and its field the same path with dots.
+func (d *Document) Check(schema *Schema, value any, strict bool) []apperrors.FieldError {
+	v := validator{doc: d, strict: strict}
+	v.check(schema, value, "")
+	return v.violations
+}
+
+// Decode reads a json body the way Validate expects it.
+func Decode(body []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("more than one json value")
+	}
+	return value, nil
+}
+
+type validator struct {
+	doc        *Document
+	strict     bool
+	violations []apperrors.FieldError
+}
+
+func (v *validator) fail(pointer string, code string, format string, args ...any) {
+	field := strings.TrimPrefix(pointer, "/")
+	field = strings.NewReplacer("/", ".", "~1", "/", "~0", "~").Replace(field)
+	v.violations = append(v.violations, apperrors.FieldError{
+		Field:   field,
+		Pointer: pointer,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		schema = v.doc.Components.Schemas[name]
+	}
+	return schema
+}
+
+func (v *validator) check(schema *Schema, value any, pointer string) {
+	schema = v.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	if len(schema.AnyOf) > 0 {
+		for _, option := range schema.AnyOf {
+			nested := validator{doc: v.doc, strict: v.strict}
+			nested.check(option, value, pointer)
+			if len(nested.violations) == 0 {
+				return
+			}
+		}
+		v.fail(pointer, CodeInvalidType, "matches none of the allowed schemas")
+		return
+	}
+
+	if types := typesOf(schema); len(types) > 0 && !slices.Contains(types, jsonType(value, types)) {
+		v.fail(pointer, CodeInvalidType, "is %s, want %s", jsonType(value, types), strings.Join(types, " or "))
+		return
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(allowed any) bool {
+		return fmt.Sprint(allowed) == fmt.Sprint(value)
+	}) {
+		v.fail(pointer, CodeInvalidChoice, "%v is not one of %v", value, schema.Enum)
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.checkString(schema, value, pointer)
+	case json.Number:
+		if schema.Minimum != nil {
+			if n, err := value.Int64(); err == nil && n < *schema.Minimum {
+				v.fail(pointer, CodeOutOfRange, "%d is below the minimum %d", n, *schema.Minimum)
+			}
+		}
+	case []any:
+		for i, item := range value {
+			v.check(schema.Items, item, fmt.Sprintf("%s/%d", pointer, i))
+		}
+	case map[string]any:
+		v.checkObject(schema, value, pointer)
+	}
+}
+
+func (v *validator) checkString(schema *Schema, value string, pointer string) {
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			v.fail(pointer, CodeInvalidFormat, "%q is not a uuid", value)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			v.fail(pointer, CodeInvalidFormat, "%q is not an RFC 3339 date-time", value)
+		}
+	}
+
+	if schema.Pattern != "" && !compiled(schema.Pattern).MatchString(value) {
+		v.fail(pointer, CodeInvalidFormat, "%q does not match %s", value, schema.Pattern)
+	}
+}
+
+var patterns sync.Map
+
+// compiled caches the regular expressions of the schemas, which are
+// written in the code and so always compile.
+func compiled(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
+
+func (v *validator) checkObject(schema *Schema, value map[string]any, pointer string) {
+	for _, name := range schema.Required {
+		if _, ok := value[name]; !ok {
+			v.fail(pointer+"/"+escape(name), CodeRequired, "is required")
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := pointer + "/" + escape(name)
+		if property, ok := schema.Properties[name]; ok {
+			v.check(property, value[name], child)
+			continue
+		}
+
+		switch additional := schema.AdditionalProperties.(type) {
+		case *Schema:
+			v.check(additional, value[name], child)
+		case bool:
+			if !additional {
+				v.fail(child, CodeUnknownField, "is not allowed")
+			}
+		default:
+			if v.strict && schema.Properties != nil {
+				v.fail(child, CodeUnknownField, "is not documented")
+			}
+		}
+	}
+}
+
+// escape makes a property name a json pointer token (RFC 6901).
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+func typesOf(schema *Schema) []string {
+	switch typ := schema.Type.(type) {
+	case string:
+		return []string{typ}
+	case []string:
+		return typ
+	}
+	return nil
+}
+
+// jsonType names the json type of a decoded value. Whole numbers are
+// integers, unless only number is allowed.
+func jsonType(value any, allowed []string) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if _, err := value.Int64(); err == nil && !slices.Contains(allowed, "number") {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/exchange"
+	"github.com/TheMikeKaisen/CarManagement/graph"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	adminHandler "github.com/TheMikeKaisen/CarManagement/handler/admin"
+	carHandler "github.com/TheMikeKaisen/CarManagement/handler/car"
+	engineHandler "github.com/TheMikeKaisen/CarManagement/handler/engine"
+	eventsHandler "github.com/TheMikeKaisen/CarManagement/handler/events"
+	healthHandler "github.com/TheMikeKaisen/CarManagement/handler/health"
+	"github.com/TheMikeKaisen/CarManagement/openapi"
+	carService "github.com/TheMikeKaisen/CarManagement/service/car"
+	engineService "github.com/TheMikeKaisen/CarManagement/service/engine"
+	"github.com/TheMikeKaisen/CarManagement/service/purge"
+	"github.com/TheMikeKaisen/CarManagement/store/memory"
+)
+
+// runOpenAPI implements `openapi [check]`. Without arguments it prints the
+// document. check drives the real handlers through every operation, on a
+// throwaway in-memory store, and fails when a response differs from the
+// document: a status, header, media type or body shape it does not list.
+func runOpenAPI(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		doc, _, _, err := newOpenAPIApp()
+		if err != nil {
+			return err
+		}
+		out := json.NewEncoder(os.Stdout)
+		out.SetIndent("", "  ")
+		return out.Encode(doc)
+	}
+	if args[0] != "check" {
+		return fmt.Errorf("usage: openapi [check]")
+	}
+
+	drift, operations, err := checkOpenAPI(ctx)
+	if err != nil {
+		return err
+	}
+	for _, difference := range drift {
+		fmt.Println(difference)
+	}
+	if len(drift) > 0 {
+		return fmt.Errorf("the handlers differ from the openapi document in %d places", len(drift))
+	}
+	fmt.Printf("all %d operations match the openapi document\n", operations)
+	return nil
+}
+
+// newOpenAPIApp wires the handlers to a throwaway in-memory store, the way
+// main does, and returns the document built from their routes and the
+// router serving them.
+func newOpenAPIApp() (*openapi.Document, http.Handler, *memory.Store, error) {
+	mem := memory.New()
+	rates, err := exchange.Parse(strings.NewReader(`{"base":"USD","rates":{"EUR":"0.92"}}`))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	policy := auth.DefaultPolicy()
+	broker := events.NewBroker(0, policy)
+	carSvc := carService.NewCarService(mem, rates, policy, broker)
+	engineSvc := engineService.NewEngineStore(mem, policy, broker)
+	purger := purge.NewPurger(mem, mem, 0, policy)
+
+	endpoints := apiEndpoints(
+		carHandler.NewCarHandler(carSvc),
+		engineHandler.NewCarHandler(engineSvc),
+		adminHandler.NewAdminHandler(purger),
+	)
+	doc := openapi.Build(apiInfo, apiPrefix, endpoints, newGenerator())
+
+	authenticate := handler.Authenticate(auth.NewAPIKeyAuthenticator(mem))
+	validate := openapi.RequestValidator(doc, defaultMaxBodyBytes, defaultMaxImportBytes)
+	router := newRouter(healthHandler.NewHealthHandler(nil), endpoints, doc, graph.NewHandler(carSvc, engineSvc, defaultMaxBodyBytes), eventsHandler.NewEventsHandler(broker), authenticate, validate)
+
+	return doc, router, mem, nil
+}
+
+// checkOpenAPI makes the requests of the drift check and returns every
+// difference found, along with how many operations the document has.
+func checkOpenAPI(ctx context.Context) ([]string, int, error) {
+	doc, router, mem, err := newOpenAPIApp()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	session := &checkSession{checker: openapi.NewChecker(doc, router)}
+	for _, role := range auth.Roles {
+		plain, key, err := auth.GenerateAPIKey("openapi-check-"+role, []string{role})
+		if err != nil {
+			return nil, 0, err
+		}
+		if _, err := mem.CreateAPIKey(ctx, key); err != nil {
+			return nil, 0, err
+		}
+		session.keys = append(session.keys, plain)
+	}
+
+	session.run()
+
+	for _, operation := range session.checker.Uncovered() {
+		session.drift = append(session.drift, operation+": no request was made to it")
+	}
+	return session.drift, len(doc.Operations()), nil
+}
+
+// checkSession makes the requests of the drift check, as an admin unless
+// told otherwise, collecting every difference found.
+type checkSession struct {
+	checker *openapi.Checker
+	keys    []string // viewer, editor and admin
+	drift   []string
+}
+
+// request describes one request of the session. Key picks the role of
+// auth.Roles to send the key of, -1 sends none.
+type request struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+	headers     []string
+	key         int
+	status      int
+}
+
+func (s *checkSession) do(req request) map[string]any {
+	var body io.Reader
+	if req.body != "" {
+		body = strings.NewReader(req.body)
+	}
+	r := httptest.NewRequest(req.method, apiPrefix+req.path, body)
+	if req.contentType != "" {
+		r.Header.Set("Content-Type", req.contentType)
+	}
+	for i := 0; i+1 < len(req.headers); i += 2 {
+		r.Header.Set(req.headers[i], req.headers[i+1])
+	}
+	if req.key >= 0 {
+		r.Header.Set(auth.APIKeyHeader, s.keys[req.key])
+	}
+
+	recorder, drift := s.checker.Do(r)
+	s.drift = append(s.drift, drift...)
+	if req.status != 0 && recorder.Code != req.status {
+		s.drift = append(s.drift, fmt.Sprintf("%s %s answered %d, the check expected %d: %s",
+			req.method, req.path, recorder.Code, req.status, strings.TrimSpace(recorder.Body.String())))
+	}
+
+	var decoded map[string]any
+	json.Unmarshal(recorder.Body.Bytes(), &decoded)
+	if decoded == nil {
+		decoded = map[string]any{}
+	}
+	decoded["etag"] = recorder.Header().Get("ETag")
+	return decoded
+}
+
+const (
+	asNobody = -1
+	asViewer = 0
+	asEditor = 1
+	asAdmin  = 2
+)
+
+func (s *checkSession) get(path string, status int, headers ...string) map[string]any {
+	return s.do(request{method: http.MethodGet, path: path, headers: headers, key: asAdmin, status: status})
+}
+
+func (s *checkSession) send(method string, path string, body string, status int, headers ...string) map[string]any {
+	return s.do(request{method: method, path: path, contentType: "application/json", body: body, headers: headers, key: asAdmin, status: status})
+}
+
+// run makes at least one request to every operation, and the error
+// responses that are easy to provoke.
+func (s *checkSession) run() {
+	const (
+		engineBody = `{"displacement":2000,"no_of_cylinders":4,"car_range":600}`
+		missingID  = "7d1b4a6e-9f1c-4c2a-8a3b-000000000000"
+	)
+
+	// authentication and roles
+	s.do(request{method: http.MethodGet, path: "/cars", key: asNobody, status: 401})
+	s.do(request{method: http.MethodPost, path: "/engines", contentType: "application/json", body: engineBody, key: asViewer, status: 403})
+	s.do(request{method: http.MethodGet, path: "/cars?include_deleted=true", key: asViewer, status: 403})
+
+	// engines
+	s.send(http.MethodPost, "/engines", `{"displacement":`, 400)
+	s.send(http.MethodPost, "/engines", `{"displacement":-1,"no_of_cylinders":0,"car_range":0}`, 422)
+	engine := s.do(request{method: http.MethodPost, path: "/engines", contentType: "application/json", body: engineBody, key: asEditor, status: 200})
+	engineID, _ := engine["engine_id"].(string)
+	enginePath := "/engines/" + engineID
+
+	s.get(enginePath, 200)
+	s.get(enginePath, 304, "If-None-Match", engine["etag"].(string))
+	s.get("/engines/"+missingID, 404)
+	s.get("/engines/not-a-uuid", 400)
+	s.get("/engines?include=car_count&limit=1&sort=-displacement", 200)
+	s.get("/engines?limit=many", 400)
+	s.get("/engines?sort=colour", 422)
+	s.send(http.MethodPut, enginePath, `{"displacement":2200,"no_of_cylinders":4,"car_range":650}`, 200)
+	s.send(http.MethodPut, enginePath, engineBody, 412, "If-Match", `"stale"`)
+	s.send(http.MethodPatch, enginePath, `{"car_range":700}`, 200)
+	s.do(request{method: http.MethodPatch, path: enginePath, body: `{"car_range":710}`, key: asAdmin, status: 200})
+	s.do(request{method: http.MethodPatch, path: enginePath, contentType: "application/json-patch+json",
+		body: `[{"op":"replace","path":"/displacement","value":2100}]`, key: asAdmin, status: 200})
+	s.do(request{method: http.MethodPatch, path: enginePath, contentType: "text/plain", body: "x", key: asAdmin, status: 415})
+
+	// cars, with the specs of the engine as patched above
+	carBody := `{"name":"Civic","year":"2021","brand":"Honda","fuel_type":"Petrol","engine":{"engine_id":"` + engineID + `","displacement":2100,"no_of_cylinders":4,"car_range":710},"price":{"amount_minor":2500000,"currency":"USD"}}`
+	s.send(http.MethodPost, "/cars", `{"name":`, 400)
+	s.send(http.MethodPost, "/cars", `{"name":"","year":"1","brand":"","fuel_type":"Coal","engine":{"displacement":0,"no_of_cylinders":0,"car_range":0},"price":{"amount_minor":-1,"currency":"USD"}}`, 422)
+	s.send(http.MethodPost, "/cars", strings.Replace(carBody, `"name"`, `"colour":"red","name"`, 1), 400)
+	s.send(http.MethodPost, "/cars", strings.Replace(carBody, `"car_range":710`, `"car_range":"far"`, 1), 400)
+	s.send(http.MethodPost, "/cars", strings.Replace(carBody, `"car_range":710`, `"car_range":600`, 1), 422)
+	s.send(http.MethodPost, "/cars", `{"name":"`+strings.Repeat("x", defaultMaxBodyBytes)+`"}`, 413)
+	s.send(http.MethodPost, "/cars", strings.Replace(carBody, engineID, missingID, 1), 422)
+	car := s.send(http.MethodPost, "/cars", carBody, 200)
+	carID, _ := car["id"].(string)
+	carPath := "/cars/" + carID
+
+	s.get(carPath, 200)
+	s.get(carPath, 304, "If-None-Match", car["etag"].(string))
+	s.get(carPath+"?as_of=2100-01-01T00:00:00Z", 200)
+	s.get(carPath+"?as_of=yesterday", 400)
+	s.get("/cars/"+missingID, 404)
+	s.get("/cars/brand?brand=Honda&isEngine=true&currency=EUR", 200)
+	s.get("/cars?brand=Honda&currency=EUR&price_currency=USD&sort=-price&limit=5", 200)
+	s.get("/cars?sort=-price", 422)
+	s.get("/cars?min_year=old", 400)
+	s.get("/cars?currency=euro", 422)
+	s.send(http.MethodPut, carPath, strings.Replace(carBody, "Civic", "Civic Si", 1), 200)
+	s.send(http.MethodPut, carPath, carBody, 412, "If-Match", `"stale"`)
+	s.send(http.MethodPatch, carPath, `{"price":{"amount_minor":2400000,"currency":"USD"}}`, 200)
+	s.send(http.MethodPatch, carPath, `{"year":"1800"}`, 422)
+	s.get(carPath+"/history", 200)
+	s.get(carPath+"/prices", 200)
+	s.get("/cars/prices/stats?group_by=year", 200)
+	s.get("/cars/prices/stats?group_by=colour", 400)
+
+	// files
+	s.get("/exports/cars?format=ndjson&currency=EUR", 200)
+	s.get("/exports/cars", 200, "Accept", "text/csv")
+	s.get("/exports/cars?format=xlsx", 200)
+	s.get("/exports/cars?format=pdf", 400)
+	s.get("/exports/cars", 406, "Accept", "image/png")
+	s.do(request{method: http.MethodPost, path: "/imports", contentType: "text/csv", key: asEditor, status: 200,
+		body: "name,year,brand,fuel_type,price,displacement,no_of_cylinders,car_range\nJazz,2020,Honda,Petrol,18000,1200,4,500\nBad,x,,,,,,\n"})
+	s.do(request{method: http.MethodPost, path: "/imports?dry_run=true", contentType: "application/x-ndjson", key: asEditor, status: 200,
+		body: strings.Replace(carBody, "Civic", "Accord", 1) + "\n"})
+	s.do(request{method: http.MethodPost, path: "/imports", contentType: "text/plain", body: "x", key: asEditor, status: 415})
+	s.do(request{method: http.MethodPost, path: "/imports", contentType: "text/csv", body: "", key: asEditor, status: 400})
+
+	// deletes and restores
+	s.send(http.MethodDelete, enginePath, "", 409)
+	s.send(http.MethodDelete, enginePath+"?cascade=sideways", "", 400)
+	s.send(http.MethodDelete, carPath, "", 200)
+	s.get(carPath+"/history", 404)
+	s.get(carPath+"/history?include_deleted=true", 200)
+	s.do(request{method: http.MethodGet, path: carPath + "/history?include_deleted=true", key: asViewer, status: 403})
+	s.send(http.MethodPost, carPath+"/restore", "", 200)
+	s.send(http.MethodDelete, enginePath+"?cascade=delete", "", 200)
+	s.send(http.MethodPost, enginePath+"/restore", "", 200)
+	s.get(enginePath+"/history", 200)
+	// a car whose engine was detached has a null engine
+	s.send(http.MethodPost, carPath+"/restore", "", 200)
+	s.send(http.MethodDelete, enginePath+"?cascade=detach", "", 200)
+	if detached := s.get(carPath, 200); detached["engine"] != nil {
+		s.drift = append(s.drift, fmt.Sprintf("GET %s answered the engine %v of a detached car, the check expected null", carPath, detached["engine"]))
+	}
+	s.send(http.MethodPost, "/admin/purge", "", 200)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+// TestOpenAPIMatchesHandlers runs the drift check of `openapi check`, so a
+// handler answering with a status, header or body the document does not
+// list fails the tests too.
+func TestOpenAPIMatchesHandlers(t *testing.T) {
+	drift, operations, err := checkOpenAPI(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, difference := range drift {
+		t.Error(difference)
+	}
+	if operations == 0 {
+		t.Fatal("the openapi document has no operations")
+	}
+}
@@ -0,0 +1,360 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/exports"
+	adminHandler "github.com/TheMikeKaisen/CarManagement/handler/admin"
+	carHandler "github.com/TheMikeKaisen/CarManagement/handler/car"
+	engineHandler "github.com/TheMikeKaisen/CarManagement/handler/engine"
+	eventsHandler "github.com/TheMikeKaisen/CarManagement/handler/events"
+	healthHandler "github.com/TheMikeKaisen/CarManagement/handler/health"
+	"github.com/TheMikeKaisen/CarManagement/imports"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/openapi"
+	"github.com/TheMikeKaisen/CarManagement/patch"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// where the api document and its viewer are served, outside the api so
+// they need no credentials
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+)
+
+// where the GraphQL api is served, under the api prefix and its middleware.
+// It is not a route of the document, the request validator lets it through
+// and the schema checks the queries.
+const graphQLPath = "/graphql"
+
+// where the changes of the catalog are streamed as server-sent events,
+// also outside the document: a stream has no response to check
+const eventsPath = "/events"
+
+var apiInfo = openapi.Info{
+	Title:       "Car Management API",
+	Version:     "1",
+	Description: "Cars and the engines they use, with their price and audit history.",
+}
+
+// the parameters shared by several routes
+var (
+	carID    = openapi.PathParam("id", uuid.UUID{}, "id of the car")
+	engineID = openapi.PathParam("id", uuid.UUID{}, "id of the engine")
+
+	includeDeleted = openapi.Query("include_deleted", true, "also show soft deleted records, needs "+string(auth.PermReadDeleted))
+	asOf           = openapi.Query("as_of", time.Time{}, "the record as it was at this time, read back from its history")
+	currency       = openapi.Query("currency", nil, "also show the prices converted to this ISO 4217 currency")
+	ifMatch        = openapi.HeaderParam("If-Match", "only write when the record still has this ETag")
+	ifNoneMatch    = openapi.HeaderParam("If-None-Match", "answer 304 when the record still has this ETag")
+
+	pageParams = []openapi.Param{
+		openapi.Query("sort", nil, "comma separated fields, - in front sorts descending, e.g. brand,-price"),
+		openapi.Query("limit", 0, "page size"),
+		openapi.Query("cursor", nil, "next_cursor of the previous page"),
+	}
+	engineFilterParams = []openapi.Param{
+		openapi.Query("min_displacement", int64(0), ""),
+		openapi.Query("max_displacement", int64(0), ""),
+		openapi.Query("min_no_of_cylinders", int64(0), ""),
+		openapi.Query("max_no_of_cylinders", int64(0), ""),
+		openapi.Query("min_car_range", int64(0), ""),
+		openapi.Query("max_car_range", int64(0), ""),
+	}
+	carFilterParams = append([]openapi.Param{
+		openapi.Query("brand", nil, ""),
+		openapi.Query("fuel_type", nil, ""),
+		openapi.Query("min_year", 0, ""),
+		openapi.Query("max_year", 0, ""),
+		openapi.Query("min_price", nil, "decimal amount in price_currency"),
+		openapi.Query("max_price", nil, "decimal amount in price_currency"),
+		openapi.Query("price_currency", nil, "only the cars priced in this currency, needed to sort by price. The price bounds are in this currency, USD when empty"),
+		currency,
+	}, engineFilterParams...)
+)
+
+// patchBodies are the two formats a PATCH of the model is accepted in,
+// plain json being read as a merge patch.
+func patchBodies(model string) []openapi.Content {
+	mergePatch := &openapi.Schema{Type: "object", Description: "the fields of " + model + " to change"}
+	return []openapi.Content{
+		openapi.Body(string(patch.FormatMergePatch), mergePatch),
+		openapi.Body("application/json", mergePatch),
+		openapi.Body(string(patch.FormatJSONPatch), &openapi.Schema{Type: "array", Items: openapi.Ref("Operation")}),
+	}
+}
+
+// newGenerator derives the schemas from the models, adding the rules the
+// validators enforce that the types do not show.
+func newGenerator() *openapi.Generator {
+	g := openapi.NewGenerator()
+
+	g.Schema(patch.Operation{})
+	g.Refine(patch.Operation{}, func(schema *openapi.Schema) {
+		schema.Description = "an RFC 6902 JSON Patch operation"
+		schema.Properties["op"].Enum = []any{"add", "remove", "replace", "move", "copy", "test"}
+	})
+	// a car names its engine by engine_id and its specs have to match the
+	// engine, while an import may name it by the specs alone
+	g.Refine(models.CarRequest{}, func(schema *openapi.Schema) {
+		schema.Properties["engine"] = g.Define("CarEngine", &openapi.Schema{
+			Type:        "object",
+			Description: "the engine of a car, named by engine_id; the specs have to match that engine or the write is rejected with 422",
+			Properties: map[string]*openapi.Schema{
+				"engine_id":       {Type: "string", Format: "uuid"},
+				"displacement":    {Type: "integer", Format: "int64"},
+				"no_of_cylinders": {Type: "integer", Format: "int64"},
+				"car_range":       {Type: "integer", Format: "int64"},
+				"version":         {Type: "integer", Format: "int64", Description: "ignored, the version of the engine is its own"},
+			},
+			Required: []string{"displacement", "no_of_cylinders", "car_range"},
+		})
+	})
+	// Car.MarshalJSON writes the engine of a detached car as null
+	g.Refine(models.Car{}, func(schema *openapi.Schema) {
+		schema.Properties["engine"] = openapi.Nullable(schema.Properties["engine"])
+	})
+	g.Refine(models.Money{}, func(schema *openapi.Schema) {
+		schema.Description = "an amount in the minor unit of an ISO 4217 currency, e.g. cents"
+		schema.Properties["currency"].Pattern = "^[A-Z]{3}$"
+	})
+	g.Refine(models.AuditEntry{}, func(schema *openapi.Schema) {
+		schema.Properties["entity"].Enum = []any{models.EntityCar, models.EntityEngine}
+		schema.Properties["operation"].Enum = []any{
+			models.OperationCreate, models.OperationUpdate, models.OperationDelete, models.OperationRestore, models.OperationPurge,
+		}
+	})
+	g.Refine(models.ImportResult{}, func(schema *openapi.Schema) {
+		schema.Properties["status"].Enum = []any{models.ImportCreated, models.ImportValid, models.ImportFailed, models.ImportSkipped}
+	})
+	return g
+}
+
+// apiEndpoints is the route table of the api, the router and the openapi
+// document are both built from it.
+func apiEndpoints(cars *carHandler.CarHandler, engines *engineHandler.EngineHandler, admin *adminHandler.AdminHandler) []openapi.Endpoint {
+	carWrite := string(auth.PermCarsWrite)
+	carRead := string(auth.PermCarsRead)
+	carDelete := string(auth.PermCarsDelete)
+	engineWrite := string(auth.PermEnginesWrite)
+	engineRead := string(auth.PermEnginesRead)
+	engineDelete := string(auth.PermEnginesDelete)
+
+	var endpoints []openapi.Endpoint
+	add := func(endpoint openapi.Endpoint) {
+		endpoints = append(endpoints, endpoint)
+	}
+	withETag := func(reply openapi.Reply) openapi.Reply {
+		reply.Headers = append(reply.Headers, "ETag")
+		return reply
+	}
+	notModified := openapi.Reply{Status: http.StatusNotModified, Description: "the copy in If-None-Match is current", Headers: []string{"ETag"}}
+
+	// car routes
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars", Handler: cars.ListCars,
+		ID: "listCars", Summary: "List cars", Tag: "cars", Permission: carRead,
+		Params:    append(append(append([]openapi.Param{}, carFilterParams...), pageParams...), includeDeleted),
+		Responses: append([]openapi.Reply{openapi.OK("a page of cars", openapi.JSON(models.CarPage{}))}, openapi.Problems(400, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/cars", Handler: cars.CreateCar,
+		ID: "createCar", Summary: "Create a car", Tag: "cars", Permission: carWrite,
+		Description: "The engine is referenced by engine_id, an unknown one is a validation error.",
+		Body:        []openapi.Content{openapi.JSON(models.CarRequest{})},
+		Responses:   append([]openapi.Reply{withETag(openapi.OK("the created car", openapi.JSON(models.Car{})))}, openapi.Problems(400, 422)...),
+	})
+	// registered before /cars/{id} so "brand" is not taken for an id
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars/brand", Handler: cars.GetCarByBrand,
+		ID: "getCarsByBrand", Summary: "List the cars of a brand", Tag: "cars", Permission: carRead,
+		Params: []openapi.Param{
+			openapi.Query("brand", nil, ""),
+			openapi.Query("isEngine", true, "include the engine of every car"),
+			currency, includeDeleted,
+		},
+		Responses: append([]openapi.Reply{openapi.OK("the cars of the brand", openapi.JSON([]models.Car{}))}, openapi.Problems(400, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars/{id}", Handler: cars.GetCarById,
+		ID: "getCar", Summary: "Get a car", Tag: "cars", Permission: carRead,
+		Params:    []openapi.Param{carID, includeDeleted, asOf, ifNoneMatch},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the car", openapi.JSON(models.Car{}))), notModified}, openapi.Problems(400, 404)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPut, Path: "/cars/{id}", Handler: cars.UpdateCar,
+		ID: "updateCar", Summary: "Replace a car", Tag: "cars", Permission: carWrite,
+		Params:    []openapi.Param{carID, ifMatch},
+		Body:      []openapi.Content{openapi.JSON(models.CarRequest{})},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the updated car", openapi.JSON(models.Car{})))}, openapi.Problems(400, 404, 409, 412, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPatch, Path: "/cars/{id}", Handler: cars.PatchCar,
+		ID: "patchCar", Summary: "Change some fields of a car", Tag: "cars", Permission: carWrite,
+		Params:    []openapi.Param{carID, ifMatch},
+		Body:      patchBodies("CarRequest"),
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the patched car", openapi.JSON(models.Car{})))}, openapi.Problems(400, 404, 409, 412, 415, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodDelete, Path: "/cars/{id}", Handler: cars.DeleteCar,
+		ID: "deleteCar", Summary: "Soft delete a car", Tag: "cars", Permission: carDelete,
+		Params:    []openapi.Param{carID, ifMatch},
+		Responses: append([]openapi.Reply{openapi.OK("the deleted car", openapi.JSON(models.Car{}))}, openapi.Problems(400, 404, 409, 412)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/cars/{id}/restore", Handler: cars.RestoreCar,
+		ID: "restoreCar", Summary: "Undo the soft delete of a car", Tag: "cars", Permission: carDelete,
+		Params:    []openapi.Param{carID},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the restored car", openapi.JSON(models.Car{})))}, openapi.Problems(400, 404, 409)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars/{id}/history", Handler: cars.CarHistory,
+		ID: "getCarHistory", Summary: "Audit trail of a car", Tag: "cars", Permission: carRead,
+		Params:    []openapi.Param{carID, includeDeleted},
+		Responses: append([]openapi.Reply{openapi.OK("every write of the car, oldest first", openapi.JSON(models.History{}))}, openapi.Problems(400, 404)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars/{id}/prices", Handler: cars.CarPrices,
+		ID: "getCarPrices", Summary: "Price timeline of a car", Tag: "prices", Permission: carRead,
+		Params:    []openapi.Param{carID, includeDeleted},
+		Responses: append([]openapi.Reply{openapi.OK("the prices of the car, oldest first", openapi.JSON(models.PriceTimeline{}))}, openapi.Problems(400, 404)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/cars/prices/stats", Handler: cars.PriceStats,
+		ID: "getPriceStats", Summary: "Price statistics", Tag: "prices", Permission: carRead,
+		Params: []openapi.Param{
+			{Name: "group_by", In: "query", Description: "brand when empty", Enum: models.PriceGroupFields},
+			includeDeleted,
+		},
+		Responses: append([]openapi.Reply{openapi.OK("count, average, min and max price per group and currency", openapi.JSON(models.PriceReport{}))}, openapi.Problems(400, 422)...),
+	})
+
+	// engine routes
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/engines", Handler: engines.ListEngines,
+		ID: "listEngines", Summary: "List engines", Tag: "engines", Permission: engineRead,
+		Params: append(append(append([]openapi.Param{}, engineFilterParams...), pageParams...),
+			openapi.Param{Name: "include", In: "query", Description: "car_count adds the number of cars using each engine", Enum: []string{"car_count"}},
+			includeDeleted,
+		),
+		Responses: append([]openapi.Reply{openapi.OK("a page of engines", openapi.JSON(models.EnginePage{}))}, openapi.Problems(400, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/engines", Handler: engines.CreateEngine,
+		ID: "createEngine", Summary: "Create an engine", Tag: "engines", Permission: engineWrite,
+		Body:      []openapi.Content{openapi.JSON(models.EngineRequest{})},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the created engine", openapi.JSON(models.Engine{})))}, openapi.Problems(400, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/engines/{id}", Handler: engines.GetEngineById,
+		ID: "getEngine", Summary: "Get an engine", Tag: "engines", Permission: engineRead,
+		Params:    []openapi.Param{engineID, includeDeleted, asOf, ifNoneMatch},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the engine", openapi.JSON(models.Engine{}))), notModified}, openapi.Problems(400, 404)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPut, Path: "/engines/{id}", Handler: engines.UpdateEngine,
+		ID: "updateEngine", Summary: "Replace an engine", Tag: "engines", Permission: engineWrite,
+		Params:    []openapi.Param{engineID, ifMatch},
+		Body:      []openapi.Content{openapi.JSON(models.EngineRequest{})},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the updated engine", openapi.JSON(models.Engine{})))}, openapi.Problems(400, 404, 409, 412, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPatch, Path: "/engines/{id}", Handler: engines.PatchEngine,
+		ID: "patchEngine", Summary: "Change some fields of an engine", Tag: "engines", Permission: engineWrite,
+		Params:    []openapi.Param{engineID, ifMatch},
+		Body:      patchBodies("EngineRequest"),
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the patched engine", openapi.JSON(models.Engine{})))}, openapi.Problems(400, 404, 409, 412, 415, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodDelete, Path: "/engines/{id}", Handler: engines.DeleteEngine,
+		ID: "deleteEngine", Summary: "Soft delete an engine", Tag: "engines", Permission: engineDelete,
+		Description: "An engine still used by cars is refused unless cascade says what happens to them.",
+		Params: []openapi.Param{
+			engineID,
+			{Name: "cascade", In: "query", Description: "detach clears the engine of its cars, delete deletes them too", Enum: []string{string(models.CascadeDetach), string(models.CascadeDelete)}},
+			ifMatch,
+		},
+		Responses: append([]openapi.Reply{openapi.OK("the deleted engine", openapi.JSON(models.Engine{}))}, openapi.Problems(400, 404, 409, 412, 422)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/engines/{id}/restore", Handler: engines.RestoreEngine,
+		ID: "restoreEngine", Summary: "Undo the soft delete of an engine", Tag: "engines", Permission: engineDelete,
+		Params:    []openapi.Param{engineID},
+		Responses: append([]openapi.Reply{withETag(openapi.OK("the restored engine", openapi.JSON(models.Engine{})))}, openapi.Problems(400, 404, 409)...),
+	})
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/engines/{id}/history", Handler: engines.EngineHistory,
+		ID: "getEngineHistory", Summary: "Audit trail of an engine", Tag: "engines", Permission: engineRead,
+		Params:    []openapi.Param{engineID, includeDeleted},
+		Responses: append([]openapi.Reply{openapi.OK("every write of the engine, oldest first", openapi.JSON(models.History{}))}, openapi.Problems(400, 404)...),
+	})
+
+	// the filtered car list as a csv, ndjson or xlsx file
+	add(openapi.Endpoint{
+		Method: http.MethodGet, Path: "/exports/cars", Handler: cars.ExportCars,
+		ID: "exportCars", Summary: "Export cars", Tag: "files", Permission: carRead,
+		Description: "Takes the filters and sort of GET /cars and streams every matching car. ?format= wins over the Accept header, csv is the default.",
+		Params: append(append(append([]openapi.Param{
+			{Name: "format", In: "query", Enum: []string{"csv", "ndjson", "xlsx"}},
+		}, carFilterParams...), openapi.Query("sort", nil, "as for GET /cars")), includeDeleted),
+		Responses: append([]openapi.Reply{openapi.OK("the cars as a file",
+			openapi.Body(string(exports.FormatCSV), nil),
+			openapi.Body(string(exports.FormatNDJSON), openapi.Ref("Car")),
+			openapi.Body(string(exports.FormatXLSX), nil),
+		)}, openapi.Problems(400, 406, 422)...),
+	})
+
+	// bulk import of cars, creating their engines as needed
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/imports", Handler: cars.ImportCars,
+		ID: "importCars", Summary: "Import cars", Tag: "files", Permission: carWrite,
+		Description: "Rows name an engine by engine_id or by its specs, creating it when no live engine matches, so " +
+			engineWrite + " is needed too. Csv columns are " + strings.Join(imports.Columns, ", ") + ". Ndjson lines are CarRequest objects.",
+		Params: []openapi.Param{
+			openapi.Query("dry_run", true, "check every row without writing"),
+			openapi.Query("atomic", true, "write every row or none"),
+		},
+		Body: []openapi.Content{
+			openapi.Body(string(imports.FormatCSV), &openapi.Schema{Type: "string"}),
+			openapi.Body(string(imports.FormatNDJSON), openapi.Ref("CarRequest")),
+		},
+		Responses: append([]openapi.Reply{openapi.OK("the outcome of every row", openapi.JSON(models.ImportReport{}))}, openapi.Problems(400, 415)...),
+	})
+
+	// admin routes
+	add(openapi.Endpoint{
+		Method: http.MethodPost, Path: "/admin/purge", Handler: admin.Purge,
+		ID: "purge", Summary: "Purge old soft deleted records", Tag: "admin", Permission: string(auth.PermPurge),
+		Responses: []openapi.Reply{openapi.OK("how many records were hard deleted", openapi.JSON(models.PurgeResult{}))},
+	})
+
+	return endpoints
+}
+
+// newRouter mounts the health probes and the api document at the root and
+// the endpoints, the GraphQL api and the event stream under apiPrefix,
+// behind the middleware.
+func newRouter(health *healthHandler.HealthHandler, endpoints []openapi.Endpoint, doc *openapi.Document, graphQL http.Handler, stream *eventsHandler.EventsHandler, middleware ...mux.MiddlewareFunc) *mux.Router {
+	router := mux.NewRouter()
+
+	// health probes live outside the versioned api
+	router.HandleFunc("/healthz", health.Liveness).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", health.Readiness).Methods(http.MethodGet)
+
+	router.Handle(openAPIPath, openapi.JSONHandler(doc)).Methods(http.MethodGet)
+	router.Handle(docsPath, openapi.ViewerHandler(doc.Info.Title, openAPIPath)).Methods(http.MethodGet)
+
+	api := router.PathPrefix(apiPrefix).Subrouter()
+	api.Use(middleware...)
+	for _, endpoint := range endpoints {
+		api.HandleFunc(endpoint.Path, endpoint.Handler).Methods(endpoint.Method)
+	}
+	api.Handle(graphQLPath, graphQL).Methods(http.MethodPost)
+	api.HandleFunc(eventsPath, stream.Stream).Methods(http.MethodGet)
+	return router
+}
@@ -0,0 +1,126 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/rpc/carpb"
+	"github.com/TheMikeKaisen/CarManagement/service"
+)
+
+// CarServer is the gRPC CarService, the REST car handlers for calls.
+type CarServer struct {
+	carpb.UnimplementedCarServiceServer
+	service service.CarServiceInterface
+}
+
+func NewCarServer(service service.CarServiceInterface) *CarServer {
+	return &CarServer{service: service}
+}
+
+func (s *CarServer) GetCar(ctx context.Context, req *carpb.GetCarRequest) (*carpb.Car, error) {
+	ctx = withDeleted(ctx, req.GetIncludeDeleted())
+
+	var car *models.Car
+	var err error
+	if req.GetAsOf() != nil {
+		car, err = s.service.GetCarAsOf(ctx, req.GetId(), req.GetAsOf().AsTime())
+	} else {
+		car, err = s.service.GetCarById(ctx, req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) ListCars(ctx context.Context, req *carpb.ListCarsRequest) (*carpb.ListCarsResponse, error) {
+	ctx = withDeleted(ctx, req.GetIncludeDeleted())
+
+	// the price bounds are in the price currency unless they name one
+	boundCurrency := req.GetPriceCurrency()
+	if boundCurrency == "" {
+		boundCurrency = models.DefaultCurrency
+	}
+
+	filter := models.CarFilter{
+		Brand:    req.GetBrand(),
+		FuelType: req.GetFuelType(),
+
+		MinYear:       intFromProto(req.MinYear),
+		MaxYear:       intFromProto(req.MaxYear),
+		PriceCurrency: req.GetPriceCurrency(),
+		MinPrice:      moneyFromProto(req.GetMinPrice(), boundCurrency),
+		MaxPrice:      moneyFromProto(req.GetMaxPrice(), boundCurrency),
+		Currency:      req.GetCurrency(),
+
+		MinDisplacement:  req.MinDisplacement,
+		MaxDisplacement:  req.MaxDisplacement,
+		MinNoOfCylinders: req.MinNoOfCylinders,
+		MaxNoOfCylinders: req.MaxNoOfCylinders,
+		MinCarRange:      req.MinCarRange,
+		MaxCarRange:      req.MaxCarRange,
+
+		Sort:   models.ParseSort(req.GetSort()),
+		Limit:  int(req.GetLimit()),
+		Cursor: req.GetCursor(),
+	}
+
+	page, err := s.service.ListCars(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &carpb.ListCarsResponse{Cars: carsToProto(page.Cars), NextCursor: page.NextCursor}, nil
+}
+
+func (s *CarServer) ListCarsByBrand(ctx context.Context, req *carpb.ListCarsByBrandRequest) (*carpb.ListCarsByBrandResponse, error) {
+	ctx = withDeleted(ctx, req.GetIncludeDeleted())
+
+	cars, err := s.service.GetCarByBrand(ctx, req.GetBrand(), req.GetIncludeEngine(), req.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	return &carpb.ListCarsByBrandResponse{Cars: carsToProto(cars)}, nil
+}
+
+func (s *CarServer) CreateCar(ctx context.Context, req *carpb.CreateCarRequest) (*carpb.Car, error) {
+	carReq, err := carRequestFromProto(req.GetCar())
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := s.service.CreateCar(ctx, carReq)
+	if err != nil {
+		return nil, err
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) UpdateCar(ctx context.Context, req *carpb.UpdateCarRequest) (*carpb.Car, error) {
+	carReq, err := carRequestFromProto(req.GetCar())
+	if err != nil {
+		return nil, err
+	}
+
+	car, err := s.service.UpdateCar(ctx, req.GetId(), &carReq, models.ParseETags(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) DeleteCar(ctx context.Context, req *carpb.DeleteCarRequest) (*carpb.Car, error) {
+	car, err := s.service.DeleteCar(ctx, req.GetId(), models.ParseETags(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return carToProto(car), nil
+}
+
+func (s *CarServer) RestoreCar(ctx context.Context, req *carpb.RestoreCarRequest) (*carpb.Car, error) {
+	car, err := s.service.RestoreCar(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return carToProto(car), nil
+}
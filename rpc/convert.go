@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/rpc/carpb"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func carToProto(car *models.Car) *carpb.Car {
+	message := &carpb.Car{
+		Id:             car.ID.String(),
+		Name:           car.Name,
+		Year:           car.Year,
+		Brand:          car.Brand,
+		FuelType:       car.FuelType,
+		Price:          moneyToProto(&car.Price),
+		CreatedAt:      timestamppb.New(car.CreatedAt),
+		UpdatedAt:      timestamppb.New(car.UpdatedAt),
+		Version:        car.Version,
+		DeletedAt:      timestampToProto(car.DeletedAt),
+		ConvertedPrice: moneyToProto(car.ConvertedPrice),
+		Etag:           car.ETag(),
+	}
+	// a car whose engine was detached has none
+	if car.Engine.EngineId != uuid.Nil {
+		message.Engine = engineToProto(car.Engine)
+	}
+	return message
+}
+
+func carsToProto(cars []models.Car) []*carpb.Car {
+	messages := make([]*carpb.Car, len(cars))
+	for i := range cars {
+		messages[i] = carToProto(&cars[i])
+	}
+	return messages
+}
+
+func engineToProto(engine models.Engine) *carpb.Engine {
+	return &carpb.Engine{
+		EngineId:      engine.EngineId.String(),
+		Displacement:  engine.Displacement,
+		NoOfCylinders: engine.NoOfCylinders,
+		CarRange:      engine.CarRange,
+		Version:       engine.Version,
+		DeletedAt:     timestampToProto(engine.DeletedAt),
+		CarCount:      engine.CarCount,
+		Etag:          engine.ETag(),
+	}
+}
+
+func moneyToProto(money *models.Money) *carpb.Money {
+	if money == nil {
+		return nil
+	}
+	return &carpb.Money{AmountMinor: money.AmountMinor, Currency: money.Currency}
+}
+
+func timestampToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// carRequestFromProto reads the fields of a car a client sent. Missing
+// fields are left empty for the service to report, like missing json
+// fields are.
+func carRequestFromProto(req *carpb.CarRequest) (models.CarRequest, error) {
+	carReq := models.CarRequest{
+		Name:     req.GetName(),
+		Year:     req.GetYear(),
+		Brand:    req.GetBrand(),
+		FuelType: req.GetFuelType(),
+		Engine: models.Engine{
+			Displacement:  req.GetEngine().GetDisplacement(),
+			NoOfCylinders: req.GetEngine().GetNoOfCylinders(),
+			CarRange:      req.GetEngine().GetCarRange(),
+		},
+		Price: models.Money{
+			AmountMinor: req.GetPrice().GetAmountMinor(),
+			Currency:    req.GetPrice().GetCurrency(),
+		},
+	}
+
+	if engineID := req.GetEngine().GetEngineId(); engineID != "" {
+		id, err := uuid.Parse(engineID)
+		if err != nil {
+			return carReq, apperrors.NewSchemaViolation([]apperrors.FieldError{
+				{Field: "car.engine.engine_id", Code: models.CodeInvalid, Message: "engine_id must be a uuid"},
+			})
+		}
+		carReq.Engine.EngineId = id
+	}
+	return carReq, nil
+}
+
+func engineRequestFromProto(req *carpb.EngineRequest) models.EngineRequest {
+	return models.EngineRequest{
+		Displacement:  req.GetDisplacement(),
+		NoOfCylinders: req.GetNoOfCylinders(),
+		CarRange:      req.GetCarRange(),
+	}
+}
+
+// moneyFromProto reads a price bound, in currency unless it names its own.
+func moneyFromProto(money *carpb.Money, currency string) *models.Money {
+	if money == nil {
+		return nil
+	}
+	if money.GetCurrency() != "" {
+		currency = money.GetCurrency()
+	}
+	return &models.Money{AmountMinor: money.GetAmountMinor(), Currency: currency}
+}
+
+func intFromProto(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
+
+// withDeleted marks the context to show soft deleted records when the
+// call asks for them, like ?include_deleted=true.
+func withDeleted(ctx context.Context, include bool) context.Context {
+	if !include {
+		return ctx
+	}
+	return store.WithDeleted(ctx)
+}
+
+func cascadeFromProto(cascade carpb.Cascade) models.CascadeMode {
+	switch cascade {
+	case carpb.Cascade_CASCADE_UNSPECIFIED:
+		return models.CascadeNone
+	case carpb.Cascade_CASCADE_DETACH:
+		return models.CascadeDetach
+	case carpb.Cascade_CASCADE_DELETE:
+		return models.CascadeDelete
+	}
+	// an unknown value, for the service to refuse
+	return models.CascadeMode(cascade.String())
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/rpc/carpb"
+	"github.com/TheMikeKaisen/CarManagement/service"
+)
+
+// EngineServer is the gRPC EngineService, the REST engine handlers for
+// calls.
+type EngineServer struct {
+	carpb.UnimplementedEngineServiceServer
+	service service.EngineServiceInterface
+}
+
+func NewEngineServer(service service.EngineServiceInterface) *EngineServer {
+	return &EngineServer{service: service}
+}
+
+func (s *EngineServer) GetEngine(ctx context.Context, req *carpb.GetEngineRequest) (*carpb.Engine, error) {
+	ctx = withDeleted(ctx, req.GetIncludeDeleted())
+
+	var engine models.Engine
+	var err error
+	if req.GetAsOf() != nil {
+		engine, err = s.service.GetEngineAsOf(ctx, req.GetId(), req.GetAsOf().AsTime())
+	} else {
+		engine, err = s.service.GetEngineById(ctx, req.GetId())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) ListEngines(ctx context.Context, req *carpb.ListEnginesRequest) (*carpb.ListEnginesResponse, error) {
+	ctx = withDeleted(ctx, req.GetIncludeDeleted())
+
+	filter := models.EngineFilter{
+		MinDisplacement:  req.MinDisplacement,
+		MaxDisplacement:  req.MaxDisplacement,
+		MinNoOfCylinders: req.MinNoOfCylinders,
+		MaxNoOfCylinders: req.MaxNoOfCylinders,
+		MinCarRange:      req.MinCarRange,
+		MaxCarRange:      req.MaxCarRange,
+
+		IncludeCarCount: req.GetIncludeCarCount(),
+
+		Sort:   models.ParseSort(req.GetSort()),
+		Limit:  int(req.GetLimit()),
+		Cursor: req.GetCursor(),
+	}
+
+	page, err := s.service.ListEngines(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	engines := make([]*carpb.Engine, len(page.Engines))
+	for i, engine := range page.Engines {
+		engines[i] = engineToProto(engine)
+	}
+	return &carpb.ListEnginesResponse{Engines: engines, NextCursor: page.NextCursor}, nil
+}
+
+func (s *EngineServer) CreateEngine(ctx context.Context, req *carpb.CreateEngineRequest) (*carpb.Engine, error) {
+	engineReq := engineRequestFromProto(req.GetEngine())
+
+	engine, err := s.service.CreateEngine(ctx, &engineReq)
+	if err != nil {
+		return nil, err
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) UpdateEngine(ctx context.Context, req *carpb.UpdateEngineRequest) (*carpb.Engine, error) {
+	engineReq := engineRequestFromProto(req.GetEngine())
+
+	engine, err := s.service.UpdateEngine(ctx, req.GetId(), &engineReq, models.ParseETags(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) DeleteEngine(ctx context.Context, req *carpb.DeleteEngineRequest) (*carpb.Engine, error) {
+	engine, err := s.service.DeleteEngine(ctx, req.GetId(), cascadeFromProto(req.GetCascade()), models.ParseETags(req.GetIfMatch()))
+	if err != nil {
+		return nil, err
+	}
+	return engineToProto(engine), nil
+}
+
+func (s *EngineServer) RestoreEngine(ctx context.Context, req *carpb.RestoreEngineRequest) (*carpb.Engine, error) {
+	engine, err := s.service.RestoreEngine(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return engineToProto(engine), nil
+}
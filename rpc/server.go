@@ -0,0 +1,100 @@
+// Package rpc serves the services over gRPC, next to the REST handlers.
+// The messages and services are defined in carpb/carmanagement.proto.
+package rpc
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/handler"
+	"github.com/TheMikeKaisen/CarManagement/rpc/carpb"
+	"github.com/TheMikeKaisen/CarManagement/service"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// NewServer serves the car and engine services, behind the interceptors,
+// and their descriptors through server reflection. Reflection streams are
+// not intercepted, they describe the api like /openapi.json does.
+func NewServer(cars service.CarServiceInterface, engines service.EngineServiceInterface, interceptors ...grpc.UnaryServerInterceptor) *grpc.Server {
+	// errors are mapped last, so those of the interceptors are too
+	chain := append([]grpc.UnaryServerInterceptor{statusErrors}, interceptors...)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(chain...))
+
+	carpb.RegisterCarServiceServer(server, NewCarServer(cars))
+	carpb.RegisterEngineServiceServer(server, NewEngineServer(engines))
+	reflection.Register(server)
+	return server
+}
+
+// statusErrors answers the errors of the taxonomy with the status of the
+// same code and details the REST api would answer with.
+func statusErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	resp, err := next(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return nil, err
+	}
+
+	log.Println("Error serving ", info.FullMethod, ": ", err)
+	return nil, apperrors.GRPCStatus(err).Err()
+}
+
+// Authenticate is handler.Authenticate for calls: the metadata of a call
+// are read as the headers of a request, so the authorization and x-api-key
+// keys carry a bearer token or an api key.
+func Authenticate(authenticators ...auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		r := requestOf(ctx)
+
+		var principal *auth.Principal
+		for _, authenticator := range authenticators {
+			var err error
+			principal, err = authenticator.Authenticate(r)
+			if err != nil {
+				return nil, err
+			}
+			if principal != nil {
+				break
+			}
+		}
+
+		if principal == nil {
+			return nil, apperrors.NewUnauthorized("a bearer token or an api key is required")
+		}
+
+		ctx = auth.WithPrincipal(ctx, *principal)
+		ctx = audit.WithActor(ctx, principal.Subject)
+		return next(ctx, req)
+	}
+}
+
+// Actor is handler.Actor for calls, taking the actor of the writes from
+// the x-actor metadata when authentication is off.
+func Actor(ctx context.Context, req any, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+	if actor := requestOf(ctx).Header.Get(handler.ActorHeader); actor != "" {
+		ctx = audit.WithActor(ctx, actor)
+	}
+	return next(ctx, req)
+}
+
+// requestOf makes a request with the metadata of the call as its headers,
+// for the authenticators, which read requests.
+func requestOf(ctx context.Context) *http.Request {
+	header := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	return (&http.Request{Header: header}).WithContext(ctx)
+}
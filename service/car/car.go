@@ -3,35 +3,90 @@ package car
 import (
 	"context"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/exchange"
 	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/patch"
 	"github.com/TheMikeKaisen/CarManagement/store"
 )
 
 
 type CarService struct{
 	store store.CarStoreInterface
+
+	// rates converts listed prices on request, nil when no table is loaded
+	rates *exchange.Rates
+
+	// policy decides what the principal of each call may do
+	policy auth.Policy
+
+	// events tells the clients of /events about the committed writes
+	events *events.Broker
 }
 
-func NewCarService(store store.CarStoreInterface) *CarService{
-	return &CarService{store: store}
+func NewCarService(store store.CarStoreInterface, rates *exchange.Rates, policy auth.Policy, broker *events.Broker) *CarService{
+	return &CarService{store: store, rates: rates, policy: policy, events: broker}
 }
 
 func (s *CarService) GetCarById(ctx context.Context, id string) (*models.Car, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return &models.Car{}, err
+	}
+
 	car , err := s.store.GetCarById(ctx, id);
 	if err != nil {
 		return &models.Car{}, err
 	}
 	return &car, nil
 }
-func (s *CarService) GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
+func (s *CarService) GetCarByBrand(ctx context.Context, brand string, isEngine bool, currency string) ([]models.Car, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return nil, err
+	}
+
 	cars , err := s.store.GetCarByBrand(ctx, brand, isEngine);
 	if err != nil {
 		return nil, err
 	}
+
+	// show the prices in the requested currency too
+	err = s.convertPrices(cars, currency)
+	if err != nil {
+		return nil, err
+	}
 	return cars, nil
 }
 
+func (s *CarService) ListCars(ctx context.Context, filter models.CarFilter) (models.CarPage, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return models.CarPage{}, err
+	}
+
+	// check the sort keys and page size, filling in defaults
+	err := models.ValidateCarFilter(&filter)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	page, err := s.store.ListCars(ctx, filter)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	// show the prices in the requested currency too
+	err = s.convertPrices(page.Cars, filter.Currency)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+	return page, nil
+}
+
 func (s *CarService) CreateCar(ctx context.Context, carReq models.CarRequest) (*models.Car, error) {
+	if err := s.policy.Authorize(ctx, auth.PermCarsWrite); err != nil {
+		return nil, err
+	}
 
 	// pass validation
 	err := models.ValidateRequest(carReq)
@@ -43,9 +98,13 @@ func (s *CarService) CreateCar(ctx context.Context, carReq models.CarRequest) (*
 	if err != nil {
 		return nil, err
 	}
+	s.events.Publish(events.CarCreated, createdCar)
 	return &createdCar, nil
 }
-func (s *CarService) UpdateCar(ctx context.Context, id string, carReq *models.CarRequest) (*models.Car, error) {
+func (s *CarService) UpdateCar(ctx context.Context, id string, carReq *models.CarRequest, ifMatch models.ETags) (*models.Car, error) {
+	if err := s.policy.Authorize(ctx, auth.PermCarsWrite); err != nil {
+		return nil, err
+	}
 
 	// pass validation
 	err := models.ValidateRequest(*carReq)
@@ -53,16 +112,129 @@ func (s *CarService) UpdateCar(ctx context.Context, id string, carReq *models.Ca
 		return nil, err
 	}
 
-	updatedCar , err := s.store.UpdateCar(ctx, id, *&carReq);
+	expectedVersion, err := s.expectedVersion(ctx, id, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedCar , err := s.store.UpdateCar(ctx, id, *&carReq, expectedVersion);
 	if err != nil {
 		return nil, err
 	}
+	s.events.Publish(events.CarUpdated, updatedCar)
 	return &updatedCar, nil
 }
-func (s *CarService) DeleteCar(ctx context.Context, id string) (*models.Car, error){
-	deletedCar , err := s.store.DeleteCar(ctx, id);
+// PatchCar applies a merge patch or JSON Patch document to the car. Only
+// the merged car is validated and only the columns that changed are written.
+func (s *CarService) PatchCar(ctx context.Context, id string, format patch.Format, document []byte, ifMatch models.ETags) (*models.Car, error) {
+	if err := s.policy.Authorize(ctx, auth.PermCarsWrite); err != nil {
+		return nil, err
+	}
+
+	current, err := s.store.GetCarById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+
+	// the patch must apply to the version the client has seen
+	if len(ifMatch) > 0 && !ifMatch.Match(current.ETag()) {
+		return nil, apperrors.NewPreconditionFailed("car", id)
+	}
+
+	// patch the car in its request form
+	currentReq := models.NewCarRequest(current)
+	var patchedReq models.CarRequest
+	err = patch.ApplyTo(format, currentReq, document, &patchedReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// validate the merged car
+	err = models.ValidateCarPatch(currentReq, patchedReq)
+	if err != nil {
+		return nil, err
+	}
+
+	carPatch, err := models.DiffCarRequest(currentReq, patchedReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// nothing changed
+	if carPatch.IsEmpty() {
+		return &current, nil
+	}
+
+	// the changes were worked out from current, so they are only written
+	// over that version, even without If-Match
+	patchedCar, err := s.store.PatchCar(ctx, id, carPatch, current.Version)
+	if err != nil {
+		if len(ifMatch) == 0 && apperrors.IsPreconditionFailed(err) {
+			return nil, apperrors.NewConflict("car was changed while the patch was applied, apply it again")
+		}
+		return nil, err
+	}
+	s.events.Publish(events.CarUpdated, patchedCar)
+	return &patchedCar, nil
+}
+
+func (s *CarService) DeleteCar(ctx context.Context, id string, ifMatch models.ETags) (*models.Car, error){
+	if err := s.policy.Authorize(ctx, auth.PermCarsDelete); err != nil {
+		return nil, err
+	}
+
+	expectedVersion, err := s.expectedVersion(ctx, id, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedCar , err := s.store.DeleteCar(ctx, id, expectedVersion);
+	if err != nil {
+		return nil, err
+	}
+	s.events.Publish(events.CarDeleted, deletedCar)
 	return &deletedCar, nil
 }
+
+// RestoreCar undoes the soft delete of a car.
+func (s *CarService) RestoreCar(ctx context.Context, id string) (*models.Car, error) {
+	if err := s.policy.Authorize(ctx, auth.PermCarsDelete); err != nil {
+		return nil, err
+	}
+
+	restoredCar, err := s.store.RestoreCar(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.events.Publish(events.CarRestored, restoredCar)
+	return &restoredCar, nil
+}
+
+// authorizeRead checks that cars may be read, and deleted ones too when
+// ctx includes them.
+func (s *CarService) authorizeRead(ctx context.Context) error {
+	if err := s.policy.Authorize(ctx, auth.PermCarsRead); err != nil {
+		return err
+	}
+	if store.IncludeDeleted(ctx) {
+		return s.policy.Authorize(ctx, auth.PermReadDeleted)
+	}
+	return nil
+}
+
+// expectedVersion resolves the If-Match tags of a write to the car version
+// the store has to find, or 0 when the write is unconditional.
+func (s *CarService) expectedVersion(ctx context.Context, id string, ifMatch models.ETags) (int64, error) {
+	if len(ifMatch) == 0 {
+		return 0, nil
+	}
+
+	current, err := s.store.GetCarById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if !ifMatch.Match(current.ETag()) {
+		return 0, apperrors.NewPreconditionFailed("car", id)
+	}
+	return current.Version, nil
+}
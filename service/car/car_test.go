@@ -0,0 +1,443 @@
+package car
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/exchange"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/patch"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/memory"
+	"github.com/google/uuid"
+)
+
+const missingID = "7d1b4a6e-9f1c-4c2a-8a3b-000000000000"
+
+// fixture is a car service on a fresh in-memory store holding one engine
+// and one car using it.
+type fixture struct {
+	service *CarService
+	mem     *memory.Store
+	engine  models.Engine
+	car     models.Car
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	rates, err := exchange.Parse(strings.NewReader(`{"base":"USD","rates":{"EUR":"0.92"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := auth.DefaultPolicy()
+	mem := memory.New()
+	service := NewCarService(mem, rates, policy, events.NewBroker(0, policy))
+
+	engine, err := mem.CreateEngine(ctx, &models.EngineRequest{Displacement: 2000, NoOfCylinders: 4, CarRange: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	car, err := service.CreateCar(ctx, carRequest(engine))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fixture{service: service, mem: mem, engine: engine, car: *car}
+}
+
+func carRequest(engine models.Engine) models.CarRequest {
+	return models.CarRequest{
+		Name:     "Civic",
+		Year:     "2021",
+		Brand:    "Honda",
+		FuelType: "Petrol",
+		Engine:   engine,
+		Price:    models.Money{AmountMinor: 2500000, Currency: "USD"},
+	}
+}
+
+func as(role string) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: "test", Roles: []string{role}})
+}
+
+// statusOf is the status code err is answered with, 200 without an error.
+func statusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return apperrors.HTTPStatus(err)
+}
+
+func TestCarService(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(f fixture) error
+		wantStatus int
+	}{
+		{
+			name: "get a car",
+			run: func(f fixture) error {
+				_, err := f.service.GetCarById(as(auth.RoleViewer), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get a missing car",
+			run: func(f fixture) error {
+				_, err := f.service.GetCarById(context.Background(), missingID)
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "get a car by an invalid id",
+			run: func(f fixture) error {
+				_, err := f.service.GetCarById(context.Background(), "not-a-uuid")
+				return err
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "create a car",
+			run: func(f fixture) error {
+				_, err := f.service.CreateCar(as(auth.RoleEditor), carRequest(f.engine))
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create a car as a viewer",
+			run: func(f fixture) error {
+				_, err := f.service.CreateCar(as(auth.RoleViewer), carRequest(f.engine))
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "create an invalid car",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				carReq.Year = "1800"
+				carReq.FuelType = "Coal"
+				_, err := f.service.CreateCar(context.Background(), carReq)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "create a car with a missing engine",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				carReq.Engine.EngineId = uuid.MustParse(missingID)
+				_, err := f.service.CreateCar(context.Background(), carReq)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "create a car with the specs of another engine",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				carReq.Engine.Displacement = 1600
+				_, err := f.service.CreateCar(context.Background(), carReq)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "update a car with the specs of another engine",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				carReq.Engine.CarRange = 700
+				_, err := f.service.UpdateCar(context.Background(), f.car.ID.String(), &carReq, nil)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "update a car with its etag",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				carReq.Name = "Civic Si"
+				_, err := f.service.UpdateCar(context.Background(), f.car.ID.String(), &carReq, models.ParseETags(f.car.ETag()))
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "update a car with a stale etag",
+			run: func(f fixture) error {
+				carReq := carRequest(f.engine)
+				_, err := f.service.UpdateCar(context.Background(), f.car.ID.String(), &carReq, models.ParseETags(`"stale"`))
+				return err
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name: "patch a car",
+			run: func(f fixture) error {
+				_, err := f.service.PatchCar(context.Background(), f.car.ID.String(), patch.FormatMergePatch, []byte(`{"name":"Z"}`), nil)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "patch a car into an invalid one",
+			run: func(f fixture) error {
+				_, err := f.service.PatchCar(context.Background(), f.car.ID.String(), patch.FormatMergePatch, []byte(`{"year":"1800"}`), nil)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "patch a car whose engine was detached",
+			run: func(f fixture) error {
+				if _, err := f.mem.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, 0); err != nil {
+					return err
+				}
+				_, err := f.service.PatchCar(context.Background(), f.car.ID.String(), patch.FormatMergePatch, []byte(`{"name":"Z"}`), nil)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get a deleted car",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarById(context.Background(), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "get a deleted car with the deleted ones",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarById(store.WithDeleted(as(auth.RoleAdmin)), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get a deleted car with the deleted ones as a viewer",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarById(store.WithDeleted(as(auth.RoleViewer)), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "delete a car as an editor",
+			run: func(f fixture) error {
+				_, err := f.service.DeleteCar(as(auth.RoleEditor), f.car.ID.String(), nil)
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "restore a deleted car",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.RestoreCar(context.Background(), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "list cars",
+			run: func(f fixture) error {
+				_, err := f.service.ListCars(context.Background(), models.CarFilter{Currency: "EUR"})
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "list cars by an unknown field",
+			run: func(f fixture) error {
+				_, err := f.service.ListCars(context.Background(), models.CarFilter{Sort: models.ParseSort("colour")})
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "list cars by price",
+			run: func(f fixture) error {
+				_, err := f.service.ListCars(context.Background(), models.CarFilter{Sort: models.ParseSort("-price"), PriceCurrency: "USD"})
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "list cars by price in every currency",
+			run: func(f fixture) error {
+				_, err := f.service.ListCars(context.Background(), models.CarFilter{Sort: models.ParseSort("brand,-price")})
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "history of a car",
+			run: func(f fixture) error {
+				_, err := f.service.CarHistory(context.Background(), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "history of a deleted car",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.CarHistory(as(auth.RoleViewer), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "history of a deleted car with the deleted ones",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.CarHistory(store.WithDeleted(as(auth.RoleAdmin)), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "history of a deleted car with the deleted ones as a viewer",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.CarHistory(store.WithDeleted(as(auth.RoleViewer)), f.car.ID.String())
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "a car as of before an update",
+			run: func(f fixture) error {
+				asOf := time.Now()
+				if _, err := f.service.PatchCar(context.Background(), f.car.ID.String(), patch.FormatMergePatch, []byte(`{"name":"Z"}`), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarAsOf(as(auth.RoleViewer), f.car.ID.String(), asOf)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "a deleted car as of before its delete",
+			run: func(f fixture) error {
+				asOf := time.Now()
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarAsOf(as(auth.RoleViewer), f.car.ID.String(), asOf)
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "a deleted car as of before its delete with the deleted ones",
+			run: func(f fixture) error {
+				asOf := time.Now()
+				if _, err := f.service.DeleteCar(context.Background(), f.car.ID.String(), nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetCarAsOf(store.WithDeleted(as(auth.RoleAdmin)), f.car.ID.String(), asOf)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newFixture(t))
+			if status := statusOf(err); status != tt.wantStatus {
+				t.Fatalf("answered %d (%v), want %d", status, err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// racingStore changes a car right after the service read it, like a
+// request running at the same time would.
+type racingStore struct {
+	store.CarStoreInterface
+	mem *memory.Store
+}
+
+func (s racingStore) GetCarById(ctx context.Context, id string) (models.Car, error) {
+	car, err := s.mem.GetCarById(ctx, id)
+	if err != nil {
+		return models.Car{}, err
+	}
+	name := "changed meanwhile"
+	if _, err := s.mem.PatchCar(ctx, id, models.CarPatch{Name: &name}, 0); err != nil {
+		return models.Car{}, err
+	}
+	return car, nil
+}
+
+func TestPatchCarChangedMeanwhile(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifMatch    func(car models.Car) models.ETags
+		wantStatus int
+	}{
+		{
+			name:       "without If-Match",
+			ifMatch:    func(models.Car) models.ETags { return nil },
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "with If-Match",
+			ifMatch:    func(car models.Car) models.ETags { return models.ParseETags(car.ETag()) },
+			wantStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			service := NewCarService(racingStore{CarStoreInterface: f.mem, mem: f.mem}, f.service.rates, f.service.policy, f.service.events)
+
+			_, err := service.PatchCar(ctx, f.car.ID.String(), patch.FormatJSONPatch,
+				[]byte(`[{"op":"test","path":"/name","value":"Civic"},{"op":"replace","path":"/year","value":"2022"}]`), tt.ifMatch(f.car))
+			if status := statusOf(err); status != tt.wantStatus {
+				t.Fatalf("answered %d (%v), want %d", status, err, tt.wantStatus)
+			}
+
+			car, err := f.mem.GetCarById(ctx, f.car.ID.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if car.Name != "changed meanwhile" || car.Year != f.car.Year {
+				t.Errorf("car is %s from %s, want the change made meanwhile kept", car.Name, car.Year)
+			}
+		})
+	}
+}
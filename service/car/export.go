@@ -0,0 +1,41 @@
+package car
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ExportCars validates the filter, then passes every matching car to each
+// as the store reads it. Exports are not paginated, so the filter's limit
+// and cursor are dropped.
+func (s *CarService) ExportCars(ctx context.Context, filter models.CarFilter, each func(models.Car) error) error {
+	if err := s.authorizeRead(ctx); err != nil {
+		return err
+	}
+
+	filter.Limit, filter.Cursor = 0, ""
+
+	// check the sort keys, filling in the default order
+	err := models.ValidateCarFilter(&filter)
+	if err != nil {
+		return err
+	}
+
+	// once the first car is out the export cannot fail cleanly any more
+	if filter.Currency != "" {
+		err = s.rates.CheckTarget(filter.Currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.store.ExportCars(ctx, filter, func(car models.Car) error {
+		// show the price in the requested currency too
+		cars := []models.Car{car}
+		if err := s.convertPrices(cars, filter.Currency); err != nil {
+			return err
+		}
+		return each(cars[0])
+	})
+}
@@ -0,0 +1,75 @@
+package car
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+// CarHistory returns the audit trail of a car. The trail of a car deleted
+// or purged since is only returned when ctx includes deleted records, like
+// the car itself.
+func (s *CarService) CarHistory(ctx context.Context, id string) (models.History, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return models.History{}, err
+	}
+
+	entries, err := s.store.History(ctx, models.EntityCar, id)
+	if err != nil {
+		return models.History{}, err
+	}
+	if len(entries) == 0 || (models.IsDeleted(entries) && !store.IncludeDeleted(ctx)) {
+		return models.History{}, apperrors.NewNotFound("car", id)
+	}
+	return models.History{History: entries}, nil
+}
+
+// GetCarAsOf rebuilds the car as it was at the given time from the audit
+// log, with its engine as it was at that time too. A car deleted by then,
+// or deleted since, is only returned when ctx includes deleted records.
+func (s *CarService) GetCarAsOf(ctx context.Context, id string, asOf time.Time) (*models.Car, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.store.History(ctx, models.EntityCar, id)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := models.StateAsOf(entries, asOf)
+	if !ok || (models.IsDeleted(entries) && !store.IncludeDeleted(ctx)) {
+		return nil, apperrors.NewNotFound("car", id)
+	}
+
+	var state models.CarState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	if state.DeletedAt != nil && !store.IncludeDeleted(ctx) {
+		return nil, apperrors.NewNotFound("car", id)
+	}
+
+	// a detached car had no engine
+	var engine models.Engine
+	if state.EngineId != nil {
+		engineEntries, err := s.store.History(ctx, models.EntityEngine, state.EngineId.String())
+		if err != nil {
+			return nil, err
+		}
+
+		engine.EngineId = *state.EngineId
+		if raw, ok := models.StateAsOf(engineEntries, asOf); ok {
+			if err := json.Unmarshal(raw, &engine); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	car := state.Car(engine)
+	return &car, nil
+}
@@ -0,0 +1,123 @@
+package car
+
+import (
+	"context"
+	"log"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+// ImportCars validates every row and hands the valid ones to the store,
+// reporting each row in file order. When an atomic import has an invalid
+// row nothing is written, but the other rows are still dry run so the
+// report tells which of them would have gone in.
+func (s *CarService) ImportCars(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) (models.ImportReport, error) {
+	// rows can create the engines they name, so both are needed
+	if err := s.policy.Authorize(ctx, auth.PermCarsWrite); err != nil {
+		return models.ImportReport{}, err
+	}
+	if err := s.policy.Authorize(ctx, auth.PermEnginesWrite); err != nil {
+		return models.ImportReport{}, err
+	}
+
+	report := models.ImportReport{
+		DryRun: opts.DryRun,
+		Atomic: opts.Atomic,
+		Total:  len(rows),
+		Rows:   make([]models.ImportResult, len(rows)),
+	}
+
+	// the rows that pass validation, and where they go in the report
+	var valid []models.ImportRow
+	var positions []int
+	for i, row := range rows {
+		if err := models.ValidateImportRow(row); err != nil {
+			report.Rows[i] = models.NewImportFailure(row.Line, err)
+			report.Failed++
+			continue
+		}
+		valid = append(valid, row)
+		positions = append(positions, i)
+	}
+
+	if len(valid) > 0 {
+		storeOpts := opts
+		if opts.Atomic && report.Failed > 0 {
+			storeOpts.DryRun = true
+		}
+
+		results, err := s.store.ImportCars(ctx, valid, storeOpts)
+		if err != nil {
+			return models.ImportReport{}, err
+		}
+		for i, result := range results {
+			if result.Status == models.ImportFailed {
+				report.Failed++
+			}
+			report.Rows[positions[i]] = result
+		}
+	}
+
+	// the store reports the rows it got through as created, even when the
+	// import is rolled back afterwards
+	rolledBack := opts.DryRun || (opts.Atomic && report.Failed > 0)
+
+	// engines created by the import are gone as well, including for the
+	// rows that matched them
+	created := make(map[uuid.UUID]bool)
+	for _, result := range report.Rows {
+		if result.EngineCreated && result.EngineID != nil {
+			created[*result.EngineID] = true
+		}
+	}
+
+	for i := range report.Rows {
+		result := &report.Rows[i]
+		if result.Status != models.ImportCreated {
+			continue
+		}
+		if !rolledBack {
+			report.Created++
+			continue
+		}
+
+		result.Status = models.ImportSkipped
+		if opts.DryRun {
+			result.Status = models.ImportValid
+		}
+		result.CarID = nil
+		if result.EngineID != nil && created[*result.EngineID] {
+			result.EngineID = nil
+		}
+	}
+
+	if !rolledBack {
+		s.publishImported(ctx, report)
+	}
+	return report, nil
+}
+
+// publishImported announces the cars an import created, and the engines
+// created for them, read back now that they are committed.
+func (s *CarService) publishImported(ctx context.Context, report models.ImportReport) {
+	published := make(map[uuid.UUID]bool)
+	for _, result := range report.Rows {
+		if result.Status != models.ImportCreated || result.CarID == nil {
+			continue
+		}
+
+		car, err := s.store.GetCarById(ctx, result.CarID.String())
+		if err != nil {
+			log.Println("Error reading the imported car: ", err)
+			continue
+		}
+		if result.EngineCreated && !published[car.Engine.EngineId] {
+			published[car.Engine.EngineId] = true
+			s.events.Publish(events.EngineCreated, car.Engine)
+		}
+		s.events.Publish(events.CarCreated, car)
+	}
+}
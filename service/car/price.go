@@ -0,0 +1,69 @@
+package car
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+// CarPrices returns the price timeline of a car.
+func (s *CarService) CarPrices(ctx context.Context, id string) (models.PriceTimeline, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return models.PriceTimeline{}, err
+	}
+
+	prices, err := s.store.CarPrices(ctx, id)
+	if err != nil {
+		return models.PriceTimeline{}, err
+	}
+
+	// the store has already checked the id
+	return models.PriceTimeline{CarID: uuid.MustParse(id), Prices: prices}, nil
+}
+
+// convertPrices sets the converted price of every car when a currency is
+// asked for.
+func (s *CarService) convertPrices(cars []models.Car, currency string) error {
+	if currency == "" {
+		return nil
+	}
+	if !models.IsCurrency(currency) {
+		return apperrors.NewFieldValidation([]apperrors.FieldError{
+			{Field: "currency", Code: models.CodeInvalidCurrency, Message: "currency must be an ISO 4217 code like USD"},
+		})
+	}
+
+	for i := range cars {
+		converted, err := s.rates.Convert(cars[i].Price, currency)
+		if err != nil {
+			return err
+		}
+		cars[i].ConvertedPrice = &converted
+	}
+	return nil
+}
+
+// PriceStats aggregates the current car prices, per brand unless groupBy
+// asks for fuel_type or year.
+func (s *CarService) PriceStats(ctx context.Context, groupBy string) (models.PriceReport, error) {
+	if err := s.authorizeRead(ctx); err != nil {
+		return models.PriceReport{}, err
+	}
+
+	if groupBy == "" {
+		groupBy = "brand"
+	}
+
+	err := models.ValidatePriceGroupBy(groupBy)
+	if err != nil {
+		return models.PriceReport{}, err
+	}
+
+	stats, err := s.store.PriceStats(ctx, groupBy)
+	if err != nil {
+		return models.PriceReport{}, err
+	}
+	return models.PriceReport{GroupBy: groupBy, Stats: stats}, nil
+}
@@ -2,21 +2,33 @@ package engine
 
 import (
 	"context"
-	"errors"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
 	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/patch"
 	"github.com/TheMikeKaisen/CarManagement/store"
 )
 
 type EngineService struct {
 	store store.EngineStoreInterface
+
+	// policy decides what the principal of each call may do
+	policy auth.Policy
+
+	// events tells the clients of /events about the committed writes
+	events *events.Broker
 }
 
-func NewEngineStore(store store.EngineStoreInterface) *EngineService {
-	return &EngineService{store: store}
+func NewEngineStore(store store.EngineStoreInterface, policy auth.Policy, broker *events.Broker) *EngineService {
+	return &EngineService{store: store, policy: policy, events: broker}
 }
 
 func (e *EngineService) CreateEngine(ctx context.Context, engineReq *models.EngineRequest) (models.Engine, error) {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesWrite); err != nil {
+		return models.Engine{}, err
+	}
 
 	// validate the incoming engine
 	validateErr := models.ValidateEngineRequest(*engineReq)
@@ -29,25 +41,54 @@ func (e *EngineService) CreateEngine(ctx context.Context, engineReq *models.Engi
 	if createErr != nil {
 		return models.Engine{}, createErr
 	}
+	e.events.Publish(events.EngineCreated, newEngine)
 
 	return newEngine, nil
 }
 
 func (e *EngineService) GetEngineById(ctx context.Context, engineId string) (models.Engine, error) {
+	if err := e.authorizeRead(ctx); err != nil {
+		return models.Engine{}, err
+	}
 
 	// validate engineId
 	if engineId == "" {
-		return models.Engine{}, errors.New("id cannot be empty")
+		return models.Engine{}, apperrors.NewInvalidID(engineId, nil)
 	}
 
-	engine, err := e.GetEngineById(ctx, engineId)
+	engine, err := e.store.GetEngineById(ctx, engineId)
 	if err != nil {
 		return models.Engine{}, err
 	}
 	return engine, nil
 }
 
-func (e *EngineService) UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest) (models.Engine, error) {
+func (e *EngineService) GetEnginesByIds(ctx context.Context, engineIds []string) ([]models.Engine, error) {
+	if err := e.authorizeRead(ctx); err != nil {
+		return nil, err
+	}
+
+	return e.store.GetEnginesByIds(ctx, engineIds)
+}
+
+func (e *EngineService) ListEngines(ctx context.Context, filter models.EngineFilter) (models.EnginePage, error) {
+	if err := e.authorizeRead(ctx); err != nil {
+		return models.EnginePage{}, err
+	}
+
+	// check the sort keys and page size, filling in defaults
+	err := models.ValidateEngineFilter(&filter)
+	if err != nil {
+		return models.EnginePage{}, err
+	}
+
+	return e.store.ListEngines(ctx, filter)
+}
+
+func (e *EngineService) UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest, ifMatch models.ETags) (models.Engine, error) {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesWrite); err != nil {
+		return models.Engine{}, err
+	}
 
 	// validate the incoming engine
 	validateErr := models.ValidateEngineRequest(*engineReq)
@@ -55,25 +96,149 @@ func (e *EngineService) UpdateEngine(ctx context.Context, engineId string, engin
 		return models.Engine{}, validateErr
 	}
 
-	updatedEngine, updateErr := e.store.UpdateEngine(ctx, engineId, engineReq)
+	expectedVersion, err := e.expectedVersion(ctx, engineId, ifMatch)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	updatedEngine, updateErr := e.store.UpdateEngine(ctx, engineId, engineReq, expectedVersion)
 
 	if updateErr != nil {
 		return models.Engine{}, updateErr
 	}
+	e.events.Publish(events.EngineUpdated, updatedEngine)
 
 	return updatedEngine, nil
 }
 
-func (e *EngineService) DeleteEngine(ctx context.Context, engineId string) (*models.Engine, error) {
+// PatchEngine applies a merge patch or JSON Patch document to the engine,
+// validating the merged engine and writing only the changed columns.
+func (e *EngineService) PatchEngine(ctx context.Context, engineId string, format patch.Format, document []byte, ifMatch models.ETags) (models.Engine, error) {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesWrite); err != nil {
+		return models.Engine{}, err
+	}
+
+	// validate engineId
+	if engineId == "" {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, nil)
+	}
+
+	current, err := e.store.GetEngineById(ctx, engineId)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	// the patch must apply to the version the client has seen
+	if len(ifMatch) > 0 && !ifMatch.Match(current.ETag()) {
+		return models.Engine{}, apperrors.NewPreconditionFailed("engine", engineId)
+	}
+
+	// patch the engine in its request form
+	currentReq := models.NewEngineRequest(current)
+	var patchedReq models.EngineRequest
+	if err := patch.ApplyTo(format, currentReq, document, &patchedReq); err != nil {
+		return models.Engine{}, err
+	}
+
+	// validate the merged engine
+	validateErr := models.ValidateEngineRequest(patchedReq)
+	if validateErr != nil {
+		return models.Engine{}, validateErr
+	}
+
+	enginePatch := models.DiffEngineRequest(currentReq, patchedReq)
+	if enginePatch.IsEmpty() {
+		return current, nil
+	}
+
+	// the changes were worked out from current, so they are only written
+	// over that version, even without If-Match
+	patchedEngine, patchErr := e.store.PatchEngine(ctx, engineId, enginePatch, current.Version)
+	if patchErr != nil {
+		if len(ifMatch) == 0 && apperrors.IsPreconditionFailed(patchErr) {
+			return models.Engine{}, apperrors.NewConflict("engine was changed while the patch was applied, apply it again")
+		}
+		return models.Engine{}, patchErr
+	}
+	e.events.Publish(events.EngineUpdated, patchedEngine)
+
+	return patchedEngine, nil
+}
+
+func (e *EngineService) DeleteEngine(ctx context.Context, engineId string, cascade models.CascadeMode, ifMatch models.ETags) (models.Engine, error) {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesDelete); err != nil {
+		return models.Engine{}, err
+	}
+
 	// check if id is empty
 	if engineId == "" {
-		return nil, errors.New("engine id cannot be empty")
+		return models.Engine{}, apperrors.NewInvalidID(engineId, nil)
 	}
 
-	deletedEngine, deleteErr := e.store.DeleteEngine(ctx, engineId)
+	// validate what to do with the cars of the engine
+	if err := models.ValidateCascadeMode(cascade); err != nil {
+		return models.Engine{}, err
+	}
+
+	expectedVersion, err := e.expectedVersion(ctx, engineId, ifMatch)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	deletedEngine, deleteErr := e.store.DeleteEngine(ctx, engineId, cascade, expectedVersion)
 	if deleteErr != nil {
-		return nil, deleteErr
+		return models.Engine{}, deleteErr
 	}
+	e.events.Publish(events.EngineDeleted, events.DeletedEngine{Engine: deletedEngine, Cascade: cascade})
+
+	return deletedEngine, nil
+}
 
-	return &deletedEngine, nil
+// RestoreEngine undoes the soft delete of an engine.
+func (e *EngineService) RestoreEngine(ctx context.Context, engineId string) (models.Engine, error) {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesDelete); err != nil {
+		return models.Engine{}, err
+	}
+
+	// check if id is empty
+	if engineId == "" {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, nil)
+	}
+
+	restoredEngine, err := e.store.RestoreEngine(ctx, engineId)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	e.events.Publish(events.EngineRestored, restoredEngine)
+
+	return restoredEngine, nil
+}
+
+// authorizeRead checks that engines may be read, and deleted ones too
+// when ctx includes them.
+func (e *EngineService) authorizeRead(ctx context.Context) error {
+	if err := e.policy.Authorize(ctx, auth.PermEnginesRead); err != nil {
+		return err
+	}
+	if store.IncludeDeleted(ctx) {
+		return e.policy.Authorize(ctx, auth.PermReadDeleted)
+	}
+	return nil
+}
+
+// expectedVersion resolves the If-Match tags of a write to the engine
+// version the store has to find, or 0 when the write is unconditional.
+func (e *EngineService) expectedVersion(ctx context.Context, engineId string, ifMatch models.ETags) (int64, error) {
+	if len(ifMatch) == 0 {
+		return 0, nil
+	}
+
+	current, err := e.store.GetEngineById(ctx, engineId)
+	if err != nil {
+		return 0, err
+	}
+	if !ifMatch.Match(current.ETag()) {
+		return 0, apperrors.NewPreconditionFailed("engine", engineId)
+	}
+	return current.Version, nil
 }
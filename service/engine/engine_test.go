@@ -0,0 +1,360 @@
+package engine
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/events"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/patch"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/memory"
+	"github.com/google/uuid"
+)
+
+const missingID = "7d1b4a6e-9f1c-4c2a-8a3b-000000000000"
+
+// fixture is an engine service on a fresh in-memory store holding one
+// engine and one car using it.
+type fixture struct {
+	service *EngineService
+	mem     *memory.Store
+	engine  models.Engine
+	car     models.Car
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	policy := auth.DefaultPolicy()
+	mem := memory.New()
+	service := NewEngineStore(mem, policy, events.NewBroker(0, policy))
+
+	engine, err := service.CreateEngine(ctx, &models.EngineRequest{Displacement: 2000, NoOfCylinders: 4, CarRange: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	car, err := mem.CreateCar(ctx, models.CarRequest{
+		Name:     "Civic",
+		Year:     "2021",
+		Brand:    "Honda",
+		FuelType: "Petrol",
+		Engine:   engine,
+		Price:    models.Money{AmountMinor: 2500000, Currency: "USD"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fixture{service: service, mem: mem, engine: engine, car: car}
+}
+
+func as(role string) context.Context {
+	return auth.WithPrincipal(context.Background(), auth.Principal{Subject: "test", Roles: []string{role}})
+}
+
+// statusOf is the status code err is answered with, 200 without an error.
+func statusOf(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return apperrors.HTTPStatus(err)
+}
+
+func TestEngineService(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(f fixture) error
+		wantStatus int
+	}{
+		{
+			name: "create an engine",
+			run: func(f fixture) error {
+				_, err := f.service.CreateEngine(as(auth.RoleEditor), &models.EngineRequest{Displacement: 1600, NoOfCylinders: 4, CarRange: 500})
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "create an engine as a viewer",
+			run: func(f fixture) error {
+				_, err := f.service.CreateEngine(as(auth.RoleViewer), &models.EngineRequest{Displacement: 1600, NoOfCylinders: 4, CarRange: 500})
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "create an invalid engine",
+			run: func(f fixture) error {
+				_, err := f.service.CreateEngine(context.Background(), &models.EngineRequest{Displacement: -1})
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "get an engine",
+			run: func(f fixture) error {
+				_, err := f.service.GetEngineById(as(auth.RoleViewer), f.engine.EngineId.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "get a missing engine",
+			run: func(f fixture) error {
+				_, err := f.service.GetEngineById(context.Background(), missingID)
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "update an engine with a stale etag",
+			run: func(f fixture) error {
+				_, err := f.service.UpdateEngine(context.Background(), f.engine.EngineId.String(),
+					&models.EngineRequest{Displacement: 2200, NoOfCylinders: 4, CarRange: 650}, models.ParseETags(`"stale"`))
+				return err
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name: "update an engine with its etag",
+			run: func(f fixture) error {
+				_, err := f.service.UpdateEngine(context.Background(), f.engine.EngineId.String(),
+					&models.EngineRequest{Displacement: 2200, NoOfCylinders: 4, CarRange: 650}, models.ParseETags(f.engine.ETag()))
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "patch an engine",
+			run: func(f fixture) error {
+				_, err := f.service.PatchEngine(context.Background(), f.engine.EngineId.String(), patch.FormatMergePatch, []byte(`{"car_range":700}`), nil)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "delete an engine cars still use",
+			run: func(f fixture) error {
+				_, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeNone, nil)
+				return err
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "delete an engine with an unknown cascade",
+			run: func(f fixture) error {
+				_, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), "sideways", nil)
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "delete an engine as an editor",
+			run: func(f fixture) error {
+				_, err := f.service.DeleteEngine(as(auth.RoleEditor), f.engine.EngineId.String(), models.CascadeDetach, nil)
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "restore a deleted engine",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, nil); err != nil {
+					return err
+				}
+				_, err := f.service.RestoreEngine(context.Background(), f.engine.EngineId.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "history of a deleted engine",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, nil); err != nil {
+					return err
+				}
+				_, err := f.service.EngineHistory(as(auth.RoleViewer), f.engine.EngineId.String())
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "history of a deleted engine with the deleted ones",
+			run: func(f fixture) error {
+				if _, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, nil); err != nil {
+					return err
+				}
+				_, err := f.service.EngineHistory(store.WithDeleted(as(auth.RoleAdmin)), f.engine.EngineId.String())
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "a deleted engine as of before its delete",
+			run: func(f fixture) error {
+				asOf := time.Now()
+				if _, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetEngineAsOf(as(auth.RoleViewer), f.engine.EngineId.String(), asOf)
+				return err
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "a deleted engine as of before its delete with the deleted ones",
+			run: func(f fixture) error {
+				asOf := time.Now()
+				if _, err := f.service.DeleteEngine(context.Background(), f.engine.EngineId.String(), models.CascadeDetach, nil); err != nil {
+					return err
+				}
+				_, err := f.service.GetEngineAsOf(store.WithDeleted(as(auth.RoleAdmin)), f.engine.EngineId.String(), asOf)
+				return err
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "list engines by an unknown field",
+			run: func(f fixture) error {
+				_, err := f.service.ListEngines(context.Background(), models.EngineFilter{Sort: models.ParseSort("colour")})
+				return err
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "list deleted engines as a viewer",
+			run: func(f fixture) error {
+				_, err := f.service.ListEngines(store.WithDeleted(as(auth.RoleViewer)), models.EngineFilter{})
+				return err
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run(newFixture(t))
+			if status := statusOf(err); status != tt.wantStatus {
+				t.Fatalf("answered %d (%v), want %d", status, err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeleteEngineCascade(t *testing.T) {
+	tests := []struct {
+		cascade       models.CascadeMode
+		wantEngineId  uuid.UUID
+		wantDeleted   bool
+		wantOperation string
+	}{
+		{cascade: models.CascadeDetach, wantEngineId: uuid.Nil, wantOperation: models.OperationUpdate},
+		{cascade: models.CascadeDelete, wantDeleted: true, wantOperation: models.OperationDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.cascade), func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			if tt.cascade == models.CascadeDelete {
+				tt.wantEngineId = f.engine.EngineId
+			}
+
+			if _, err := f.service.DeleteEngine(ctx, f.engine.EngineId.String(), tt.cascade, nil); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := f.service.GetEngineById(ctx, f.engine.EngineId.String()); !apperrors.IsNotFound(err) {
+				t.Fatalf("the deleted engine is still found: %v", err)
+			}
+
+			car, err := f.mem.GetCarById(store.WithDeleted(ctx), f.car.ID.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if car.Engine.EngineId != tt.wantEngineId {
+				t.Errorf("car engine is %s, want %s", car.Engine.EngineId, tt.wantEngineId)
+			}
+			if (car.DeletedAt != nil) != tt.wantDeleted {
+				t.Errorf("car deleted_at is %v, want deleted %v", car.DeletedAt, tt.wantDeleted)
+			}
+			if car.Version != f.car.Version+1 {
+				t.Errorf("car version is %d, want %d", car.Version, f.car.Version+1)
+			}
+
+			history, err := f.mem.History(ctx, models.EntityCar, f.car.ID.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last := history[len(history)-1]; last.Operation != tt.wantOperation {
+				t.Errorf("last car audit entry is %s, want %s", last.Operation, tt.wantOperation)
+			}
+		})
+	}
+}
+
+// racingStore changes an engine right after the service read it, like a
+// request running at the same time would.
+type racingStore struct {
+	store.EngineStoreInterface
+	mem *memory.Store
+}
+
+func (s racingStore) GetEngineById(ctx context.Context, engineId string) (models.Engine, error) {
+	engine, err := s.mem.GetEngineById(ctx, engineId)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	carRange := int64(900)
+	if _, err := s.mem.PatchEngine(ctx, engineId, models.EnginePatch{CarRange: &carRange}, 0); err != nil {
+		return models.Engine{}, err
+	}
+	return engine, nil
+}
+
+func TestPatchEngineChangedMeanwhile(t *testing.T) {
+	tests := []struct {
+		name       string
+		ifMatch    func(engine models.Engine) models.ETags
+		wantStatus int
+	}{
+		{
+			name:       "without If-Match",
+			ifMatch:    func(models.Engine) models.ETags { return nil },
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "with If-Match",
+			ifMatch:    func(engine models.Engine) models.ETags { return models.ParseETags(engine.ETag()) },
+			wantStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			service := NewEngineStore(racingStore{EngineStoreInterface: f.mem, mem: f.mem}, f.service.policy, f.service.events)
+
+			_, err := service.PatchEngine(ctx, f.engine.EngineId.String(), patch.FormatJSONPatch,
+				[]byte(`[{"op":"test","path":"/car_range","value":600},{"op":"replace","path":"/displacement","value":2200}]`), tt.ifMatch(f.engine))
+			if status := statusOf(err); status != tt.wantStatus {
+				t.Fatalf("answered %d (%v), want %d", status, err, tt.wantStatus)
+			}
+
+			engine, err := f.mem.GetEngineById(ctx, f.engine.EngineId.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if engine.CarRange != 900 || engine.Displacement != f.engine.Displacement {
+				t.Errorf("engine is %d cc with a range of %d, want the change made meanwhile kept", engine.Displacement, engine.CarRange)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+// EngineHistory returns the audit trail of an engine. The trail of an
+// engine deleted or purged since is only returned when ctx includes deleted
+// records, like the engine itself.
+func (e *EngineService) EngineHistory(ctx context.Context, engineId string) (models.History, error) {
+	if err := e.authorizeRead(ctx); err != nil {
+		return models.History{}, err
+	}
+
+	entries, err := e.store.History(ctx, models.EntityEngine, engineId)
+	if err != nil {
+		return models.History{}, err
+	}
+	if len(entries) == 0 || (models.IsDeleted(entries) && !store.IncludeDeleted(ctx)) {
+		return models.History{}, apperrors.NewNotFound("engine", engineId)
+	}
+	return models.History{History: entries}, nil
+}
+
+// GetEngineAsOf rebuilds the engine as it was at the given time from the
+// audit log. An engine deleted by then, or deleted since, is only returned
+// when ctx includes deleted records.
+func (e *EngineService) GetEngineAsOf(ctx context.Context, engineId string, asOf time.Time) (models.Engine, error) {
+	if err := e.authorizeRead(ctx); err != nil {
+		return models.Engine{}, err
+	}
+
+	entries, err := e.store.History(ctx, models.EntityEngine, engineId)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	raw, ok := models.StateAsOf(entries, asOf)
+	if !ok || (models.IsDeleted(entries) && !store.IncludeDeleted(ctx)) {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+
+	var engine models.Engine
+	if err := json.Unmarshal(raw, &engine); err != nil {
+		return models.Engine{}, err
+	}
+	if engine.DeletedAt != nil && !store.IncludeDeleted(ctx) {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+
+	return engine, nil
+}
@@ -2,16 +2,27 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/patch"
 )
 
 type CarServiceInterface interface {
 	GetCarById(ctx context.Context, id string) (*models.Car, error)
-	GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error)
+	GetCarByBrand(ctx context.Context, brand string, isEngine bool, currency string) ([]models.Car, error)
+	ListCars(ctx context.Context, filter models.CarFilter) (models.CarPage, error)
+	ExportCars(ctx context.Context, filter models.CarFilter, each func(models.Car) error) error
 	CreateCar(ctx context.Context, carReq models.CarRequest) (*models.Car, error)
-	UpdateCar(ctx context.Context, id string, carReq *models.CarRequest) (*models.Car, error)
-	DeleteCar(ctx context.Context, id string) (*models.Car, error)
+	UpdateCar(ctx context.Context, id string, carReq *models.CarRequest, ifMatch models.ETags) (*models.Car, error)
+	PatchCar(ctx context.Context, id string, format patch.Format, document []byte, ifMatch models.ETags) (*models.Car, error)
+	DeleteCar(ctx context.Context, id string, ifMatch models.ETags) (*models.Car, error)
+	RestoreCar(ctx context.Context, id string) (*models.Car, error)
+	CarHistory(ctx context.Context, id string) (models.History, error)
+	GetCarAsOf(ctx context.Context, id string, asOf time.Time) (*models.Car, error)
+	CarPrices(ctx context.Context, id string) (models.PriceTimeline, error)
+	PriceStats(ctx context.Context, groupBy string) (models.PriceReport, error)
+	ImportCars(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) (models.ImportReport, error)
 }
 
 type EngineServiceInterface interface {
@@ -19,7 +30,20 @@ type EngineServiceInterface interface {
 
 	GetEngineById(ctx context.Context, engineId string) (models.Engine, error)
 
-	UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest) (models.Engine, error)
+	// GetEnginesByIds reads many engines at once, leaving out those not found
+	GetEnginesByIds(ctx context.Context, engineIds []string) ([]models.Engine, error)
 
-	DeleteEngine(ctx context.Context, engineId string) (models.Engine, error)
+	ListEngines(ctx context.Context, filter models.EngineFilter) (models.EnginePage, error)
+
+	UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest, ifMatch models.ETags) (models.Engine, error)
+
+	PatchEngine(ctx context.Context, engineId string, format patch.Format, document []byte, ifMatch models.ETags) (models.Engine, error)
+
+	DeleteEngine(ctx context.Context, engineId string, cascade models.CascadeMode, ifMatch models.ETags) (models.Engine, error)
+
+	RestoreEngine(ctx context.Context, engineId string) (models.Engine, error)
+
+	EngineHistory(ctx context.Context, engineId string) (models.History, error)
+
+	GetEngineAsOf(ctx context.Context, engineId string, asOf time.Time) (models.Engine, error)
 }
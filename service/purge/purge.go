@@ -0,0 +1,76 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/auth"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+)
+
+// JobActor is the actor the audit log records for the background purges.
+const JobActor = "purge-job"
+
+// Purger hard deletes the cars and engines that have been soft deleted
+// for longer than the retention.
+type Purger struct {
+	cars      store.CarStoreInterface
+	engines   store.EngineStoreInterface
+	retention time.Duration
+	policy    auth.Policy
+}
+
+func NewPurger(cars store.CarStoreInterface, engines store.EngineStoreInterface, retention time.Duration, policy auth.Policy) *Purger {
+	return &Purger{cars: cars, engines: engines, retention: retention, policy: policy}
+}
+
+// Purge runs one pass. Cars go first so the engines they were holding on
+// to can be removed in the same pass.
+func (p *Purger) Purge(ctx context.Context) (models.PurgeResult, error) {
+	if err := p.policy.Authorize(ctx, auth.PermPurge); err != nil {
+		return models.PurgeResult{}, err
+	}
+
+	result := models.PurgeResult{Before: time.Now().Add(-p.retention)}
+
+	cars, err := p.cars.PurgeCars(ctx, result.Before)
+	if err != nil {
+		return models.PurgeResult{}, err
+	}
+	result.Cars = cars
+
+	engines, err := p.engines.PurgeEngines(ctx, result.Before)
+	if err != nil {
+		return models.PurgeResult{}, err
+	}
+	result.Engines = engines
+
+	return result, nil
+}
+
+// Run purges every interval until ctx is done.
+func (p *Purger) Run(ctx context.Context, interval time.Duration) {
+	ctx = audit.WithActor(ctx, JobActor)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := p.Purge(ctx)
+			if err != nil {
+				log.Println("Error purging deleted records: ", err)
+				continue
+			}
+			if result.Cars > 0 || result.Engines > 0 {
+				log.Printf("Purged %d cars and %d engines deleted before %s", result.Cars, result.Engines, result.Before.Format(time.RFC3339))
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+type Store struct {
+	db *store.DB
+}
+
+func New(db *store.DB) Store {
+	return Store{db: db}
+}
+
+const selectAPIKey = `SELECT id, name, prefix, hash, roles, created_at, revoked_at FROM api_key`
+
+func (s Store) CreateAPIKey(ctx context.Context, key models.APIKey) (models.APIKey, error) {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO api_key (id, name, prefix, hash, roles, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		key.ID, key.Name, key.Prefix, key.Hash, strings.Join(key.Roles, ","), key.CreatedAt,
+	)
+	if err != nil {
+		return models.APIKey{}, err
+	}
+	return key, nil
+}
+
+// APIKeyByHash finds the live key with the hash, reporting revoked and
+// unknown keys alike as not found.
+func (s Store) APIKeyByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	row := s.db.QueryRowContext(ctx, selectAPIKey+` WHERE hash=$1 AND revoked_at IS NULL`, hash)
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.APIKey{}, apperrors.NewNotFound("api key", "")
+	}
+	return key, err
+}
+
+func (s Store) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, selectAPIKey+` ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey stops a key from authenticating. Revoking it again keeps
+// the first revocation time.
+func (s Store) RevokeAPIKey(ctx context.Context, id string) (models.APIKey, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return models.APIKey{}, apperrors.NewInvalidID(id, err)
+	}
+
+	_, err := s.db.ExecContext(ctx, `UPDATE api_key SET revoked_at=$2 WHERE id=$1 AND revoked_at IS NULL`, id, time.Now())
+	if err != nil {
+		return models.APIKey{}, err
+	}
+
+	key, err := scanAPIKey(s.db.QueryRowContext(ctx, selectAPIKey+` WHERE id=$1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.APIKey{}, apperrors.NewNotFound("api key", id)
+	}
+	return key, err
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row scanner) (models.APIKey, error) {
+	var key models.APIKey
+	var roles string
+	var revokedAt sql.NullTime
+
+	err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &roles, &key.CreatedAt, &revokedAt)
+	if err != nil {
+		return models.APIKey{}, err
+	}
+
+	key.Roles = []string{}
+	if roles != "" {
+		key.Roles = strings.Split(roles, ",")
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return key, nil
+}
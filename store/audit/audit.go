@@ -0,0 +1,245 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+// AnonymousActor is recorded for writes made without a known actor.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor sets who the writes made with ctx are recorded for.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor set by WithActor.
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	if actor == "" {
+		return AnonymousActor
+	}
+	return actor
+}
+
+// NewEntry builds the audit entry of a write. before and after are the
+// states of the record around it, nil when it did not exist.
+func NewEntry(ctx context.Context, entity string, entityId string, operation string, before any, after any) (models.AuditEntry, error) {
+	entry := models.AuditEntry{
+		Entity:    entity,
+		EntityID:  entityId,
+		Operation: operation,
+		Actor:     Actor(ctx),
+		ChangedAt: time.Now(),
+	}
+
+	var err error
+	entry.Before, err = marshalState(before)
+	if err != nil {
+		return models.AuditEntry{}, err
+	}
+	entry.After, err = marshalState(after)
+	if err != nil {
+		return models.AuditEntry{}, err
+	}
+	entry.Changes, err = diff(entry.Before, entry.After)
+	if err != nil {
+		return models.AuditEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// Record appends the audit entry of a write inside its transaction.
+func Record(ctx context.Context, tx *store.Tx, entity string, entityId string, operation string, before any, after any) error {
+	entry, err := NewEntry(ctx, entity, entityId, operation, before, after)
+	if err != nil {
+		return err
+	}
+
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_log (entity, entity_id, operation, actor, changed_at, before_state, after_state, changes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		entry.Entity,
+		entry.EntityID,
+		entry.Operation,
+		entry.Actor,
+		entry.ChangedAt,
+		nullableJSON(entry.Before),
+		nullableJSON(entry.After),
+		string(changes),
+	)
+	return err
+}
+
+// History returns the audit entries of one record, oldest first.
+func History(ctx context.Context, db *store.DB, entity string, entityId string) ([]models.AuditEntry, error) {
+	query := `
+		SELECT id, entity, entity_id, operation, actor, changed_at, before_state, after_state, changes
+		FROM audit_log
+		WHERE entity = $1 AND entity_id = $2
+		ORDER BY id
+	`
+	rows, err := db.QueryContext(ctx, query, entity, entityId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var entry models.AuditEntry
+		var before, after, changes sql.NullString
+
+		err := rows.Scan(&entry.ID, &entry.Entity, &entry.EntityID, &entry.Operation, &entry.Actor, &entry.ChangedAt, &before, &after, &changes)
+		if err != nil {
+			return nil, err
+		}
+
+		if before.Valid {
+			entry.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			entry.After = json.RawMessage(after.String)
+		}
+		if changes.Valid {
+			if err := json.Unmarshal([]byte(changes.String), &entry.Changes); err != nil {
+				return nil, err
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func marshalState(state any) (json.RawMessage, error) {
+	if state == nil || (reflect.ValueOf(state).Kind() == reflect.Pointer && reflect.ValueOf(state).IsNil()) {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+func nullableJSON(state json.RawMessage) any {
+	if state == nil {
+		return nil
+	}
+	return string(state)
+}
+
+// fields that change on every write and would only add noise to a diff
+var ignoredFields = map[string]bool{"version": true, "updated_at": true}
+
+// diff lists the top level fields that differ between two states.
+func diff(before json.RawMessage, after json.RawMessage) (map[string]models.FieldChange, error) {
+	var from, to map[string]any
+	if before != nil {
+		if err := json.Unmarshal(before, &from); err != nil {
+			return nil, err
+		}
+	}
+	if after != nil {
+		if err := json.Unmarshal(after, &to); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := make(map[string]models.FieldChange)
+	for field, value := range to {
+		if !ignoredFields[field] && !reflect.DeepEqual(from[field], value) {
+			changes[field] = models.FieldChange{From: from[field], To: value}
+		}
+	}
+	for field, value := range from {
+		if _, ok := to[field]; !ok && !ignoredFields[field] {
+			changes[field] = models.FieldChange{From: value}
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return changes, nil
+}
+
+// CarState reads the row of a car inside tx the way the audit log records
+// it, or nil when there is no such car. On postgres the row stays locked
+// until the transaction ends, so the state read before a write is the one
+// the write changes.
+func CarState(ctx context.Context, tx *store.Tx, id any) (*models.CarState, error) {
+	query := `
+		SELECT id, name, year, brand, fuel_type, engine_id, price_minor, price_currency, created_at, updated_at, version, deleted_at
+		FROM car
+		WHERE id=$1`
+	if tx.Dialect() == store.Postgres {
+		query += " FOR UPDATE"
+	}
+
+	var state models.CarState
+	var engineId uuid.NullUUID
+	var deletedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, id).Scan(
+		&state.ID, &state.Name, &state.Year, &state.Brand, &state.FuelType, &engineId, &state.Price.AmountMinor, &state.Price.Currency, &state.CreatedAt, &state.UpdatedAt, &state.Version, &deletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if engineId.Valid {
+		state.EngineId = &engineId.UUID
+	}
+	if deletedAt.Valid {
+		state.DeletedAt = &deletedAt.Time
+	}
+	return &state, nil
+}
+
+// EngineState reads the row of an engine inside tx the way the audit log
+// records it, or nil when there is no such engine.
+func EngineState(ctx context.Context, tx *store.Tx, id any) (*models.Engine, error) {
+	query := `
+		SELECT id, displacement, no_of_cylinders, car_range, version, deleted_at
+		FROM engine
+		WHERE id=$1`
+	if tx.Dialect() == store.Postgres {
+		query += " FOR UPDATE"
+	}
+
+	var state models.Engine
+	var deletedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, id).Scan(
+		&state.EngineId, &state.Displacement, &state.NoOfCylinders, &state.CarRange, &state.Version, &deletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if deletedAt.Valid {
+		state.DeletedAt = &deletedAt.Time
+	}
+	return &state, nil
+}
@@ -0,0 +1,29 @@
+package car
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// History returns the audit trail of a car or an engine, oldest first.
+func (s Store) History(ctx context.Context, entity string, id string) ([]models.AuditEntry, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewInvalidID(id, err)
+	}
+	return audit.History(ctx, s.db, entity, id)
+}
+
+// record appends the audit entry of a write to the car inside tx, reading
+// its state after the write.
+func record(ctx context.Context, tx *store.Tx, id string, operation string, before *models.CarState) error {
+	after, err := audit.CarState(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return audit.Record(ctx, tx, models.EntityCar, id, operation, before, after)
+}
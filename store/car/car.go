@@ -7,39 +7,48 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
 	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
 	"github.com/google/uuid"
 )
 
+// errEngineNotFound is reported when a car references a missing engine.
+var errEngineNotFound = apperrors.NewFieldValidation([]apperrors.FieldError{
+	{Field: "engine.engine_id", Code: "not_found", Message: "engine_id does not exists in the engine table"},
+})
+
 type Store struct {
-	db *sql.DB
+	db *store.DB
 }
 
-func new(db *sql.DB) Store {
+func New(db *store.DB) Store {
 	return Store{db: db}
 }
 
 func (s Store) GetCarById(ctx context.Context, id string) (models.Car, error) {
 
-	var car models.Car
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
 
-	query := `SELECT 
-				c.id, c.name, c.year, c.brand, c.fuel_type, c.price, c.created_at, c.updated_at,
-				e.id AS engine_id, e.displacement, e.no_of_cylinders, e.car_range
-			FROM 
-				car c 
-			LEFT JOIN 
-				engine e ON c.engine_id = e.id
+	query := selectCarWithEngine + `
 			WHERE 
-				c.id = $1;`
+				c.id = $1`
+	if notDeleted := store.NotDeleted(ctx, "c"); notDeleted != "" {
+		query += " AND " + notDeleted
+	}
 
+	// the engine columns are NULL when the car was detached from its engine
 	row := s.db.QueryRowContext(ctx, query, id)
-	err := row.Scan(
-		&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType, &car.Price, &car.CreatedAt, &car.UpdatedAt,
-		&car.Engine.EngineId, &car.Engine.Displacement, &car.Engine.NoOfCylinders, &car.Engine.CarRange,
-	)
+	car, err := scanCarWithEngine(row)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Car{}, apperrors.NewNotFound("car", id)
+		}
 		return models.Car{}, err
 	}
 
@@ -48,26 +57,23 @@ func (s Store) GetCarById(ctx context.Context, id string) (models.Car, error) {
 
 func (s Store) GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
 
-	var cars []models.Car
+	cars := []models.Car{}
 	var query string
 
 	if isEngine {
-		query = `SELECT 
-				c.id, c.name, c.year, c.brand, c.fuel_type, c.price, c.created_at, c.updated_at,
-				e.id AS engine_id, e.displacement, e.no_of_cylinders, e.car_range
-			FROM 
-				car c 
-			LEFT JOIN 
-				engine e ON c.engine_id = e.id
+		query = selectCarWithEngine + `
 			WHERE 
-				c.brand = $1;`
+				c.brand = $1`
 	} else {
 		query = `SELECT 
-				id, name,year, brand, fuel_type, engine_id, price, created_at, updated_at
+				id, name,year, brand, fuel_type, engine_id, price_minor, price_currency, created_at, updated_at, version, deleted_at
 			FROM 
-				car
+				car c
 			WHERE 
-				brand = $1;`
+				c.brand = $1`
+	}
+	if notDeleted := store.NotDeleted(ctx, "c"); notDeleted != "" {
+		query += " AND " + notDeleted
 	}
 
 	// get the list of rows that matches the brand
@@ -81,20 +87,22 @@ func (s Store) GetCarByBrand(ctx context.Context, brand string, isEngine bool) (
 	for rows.Next() {
 		var car models.Car
 		if isEngine {
-			err := rows.Scan(
-				&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType, &car.Price, &car.CreatedAt, &car.UpdatedAt,
-				&car.Engine.EngineId, &car.Engine.Displacement, &car.Engine.NoOfCylinders, &car.Engine.CarRange,
-			)
+			var err error
+			car, err = scanCarWithEngine(rows)
 			if err != nil {
 				return nil, err
 			}
 		} else {
+			var deletedAt sql.NullTime
 			err := rows.Scan(
-				&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType, &car.Engine.EngineId, &car.Price, &car.CreatedAt, &car.UpdatedAt,
+				&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType, &car.Engine.EngineId, &car.Price.AmountMinor, &car.Price.Currency, &car.CreatedAt, &car.UpdatedAt, &car.Version, &deletedAt,
 			)
 			if err != nil {
 				return nil, err
 			}
+			if deletedAt.Valid {
+				car.DeletedAt = &deletedAt.Time
+			}
 		}
 
 		// append the car to the cars list
@@ -111,21 +119,46 @@ func (s Store) GetCarByBrand(ctx context.Context, brand string, isEngine bool) (
 
 }
 
-func (s Store) CreateCar(ctx context.Context, carReq models.CarRequest) (models.Car, error) {
-
-	// check whether the engineId exists in the database or not
-	var engineId uuid.UUID
-	err := s.db.QueryRowContext(ctx, `SELECT id from engine WHERE id=$1`, carReq.Engine.EngineId).Scan(&engineId)
+func (s Store) CreateCar(ctx context.Context, carReq models.CarRequest) (_ models.Car, err error) {
 
+	// to achieve atomicity, we can use the transactions function that postgres provides
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		// check if the err is no rows found err
-		if errors.Is(err, sql.ErrNoRows) {
-			fmt.Println("No Engine with that Id present")
-			return models.Car{}, errors.New("engine_id does not exists in the engine table")
+		fmt.Println("Transaction Error")
+		return models.Car{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
 		}
-		fmt.Println("Error getting engine id")
+		err = tx.Commit()
+	}()
+
+	// check whether the engineId exists in the database or not, inside the
+	// transaction so the engine cannot be deleted before the car is written
+	engine, err := engineById(ctx, tx, carReq.Engine.EngineId)
+	if err != nil {
+		return models.Car{}, err
+	}
+	if err = models.ValidateEngineSpecs(carReq.Engine, engine); err != nil {
+		return models.Car{}, err
+	}
+
+	createdCar, err := insertCar(ctx, tx, carReq)
+	if err != nil {
+		return models.Car{}, err
 	}
 
+	createdCar.Engine = engine
+	return createdCar, nil
+
+}
+
+// insertCar creates the car inside tx along with its first price and its
+// audit entry. The engine of carReq has to exist already.
+func insertCar(ctx context.Context, tx *store.Tx, carReq models.CarRequest) (models.Car, error) {
+
 	// create a new car id
 	carId := uuid.New()
 
@@ -145,27 +178,13 @@ func (s Store) CreateCar(ctx context.Context, carReq models.CarRequest) (models.
 		UpdatedAt: updated_at,
 	}
 
-	// to achieve atomicity, we can use the transactions function that postgres provides
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		fmt.Println("Transaction Error")
-		return models.Car{}, nil
-	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-			return
-		}
-		err = tx.Commit()
-	}()
-
 	query := `INSERT INTO car 
-				(id, name, year, brand, fuel_type, engine, price, create_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7. $8. $9)
-			RETURNING id, name, year, brand, fuel_type, engine, price, created_at, updated_at`
+				(id, name, year, brand, fuel_type, engine_id, price_minor, price_currency, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id, name, year, brand, fuel_type, engine_id, price_minor, price_currency, created_at, updated_at, version`
 
 	var createdCar models.Car
-	scanErr := tx.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx, query,
 
 		&newCar.ID,
@@ -173,7 +192,9 @@ func (s Store) CreateCar(ctx context.Context, carReq models.CarRequest) (models.
 		&newCar.Year,
 		&newCar.Brand,
 		&newCar.FuelType,
-		&newCar.Price,
+		&newCar.Engine.EngineId,
+		&newCar.Price.AmountMinor,
+		&newCar.Price.Currency,
 		&newCar.CreatedAt,
 		&newCar.UpdatedAt,
 	).Scan(
@@ -182,27 +203,50 @@ func (s Store) CreateCar(ctx context.Context, carReq models.CarRequest) (models.
 		&createdCar.Year,
 		&createdCar.Brand,
 		&createdCar.FuelType,
-		&createdCar.Price,
+		&createdCar.Engine.EngineId,
+		&createdCar.Price.AmountMinor,
+		&createdCar.Price.Currency,
 		&createdCar.CreatedAt,
 		&createdCar.UpdatedAt,
+		&createdCar.Version,
 	)
 
-	if scanErr != nil {
+	if err != nil {
 		fmt.Println("Error scanning the car")
-		return models.Car{}, scanErr
+		return models.Car{}, err
 	}
 
-	return createdCar, nil
+	// the price history starts with the price the car is created with
+	err = recordPrice(ctx, tx, carId.String(), createdCar.Price, createdCar.CreatedAt)
+	if err != nil {
+		fmt.Println("Error recording the price")
+		return models.Car{}, err
+	}
 
+	// audit the create in the same transaction
+	err = record(ctx, tx, carId.String(), models.OperationCreate, nil)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Car{}, err
+	}
+
+	return createdCar, nil
 }
 
-func (s Store) UpdateCar(ctx context.Context, id string, carReq *models.CarRequest) (models.Car, error) {
+// UpdateCar replaces every column of the car. expectedVersion, when not
+// zero, makes the update fail unless the car still has that version.
+func (s Store) UpdateCar(ctx context.Context, id string, carReq *models.CarRequest, expectedVersion int64) (_ models.Car, err error) {
 	var updateCar models.Car
 
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
 	// use transaction -> Either everything will complete or none will!
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return models.Car{}, nil
+		return models.Car{}, err
 	}
 
 	defer func() {
@@ -213,43 +257,98 @@ func (s Store) UpdateCar(ctx context.Context, id string, carReq *models.CarReque
 		err = tx.Commit()
 	}()
 
-	query := `
-		UPDATE car
-		SET name = $2, year=$3, brand=$4, fuel_type=$5, engine_id=$6, price=$7, updated_at=$8
-		WHERE id=$1
-		RETURNING id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at 
-	`
-	err = tx.QueryRowContext(ctx, query,
+	// the new engine has to exist as well
+	engine, err := engineById(ctx, tx, carReq.Engine.EngineId)
+	if err != nil {
+		return models.Car{}, err
+	}
+	if err = models.ValidateEngineSpecs(carReq.Engine, engine); err != nil {
+		return models.Car{}, err
+	}
+
+	// the car as it is before the update, for the audit log
+	before, err := audit.CarState(ctx, tx, id)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	updatedAt := time.Now()
+	args := []any{
 		id,
 		&carReq.Name,
 		&carReq.Year,
 		&carReq.Brand,
 		&carReq.FuelType,
 		&carReq.Engine.EngineId,
-		&carReq.Price,
-		time.Now(),
-	).Scan(
+		&carReq.Price.AmountMinor,
+		&carReq.Price.Currency,
+		updatedAt,
+	}
+
+	where := "id=$1 AND deleted_at IS NULL"
+	if expectedVersion != 0 {
+		args = append(args, expectedVersion)
+		where += " AND version=$10"
+	}
+
+	query := `
+		UPDATE car
+		SET name = $2, year=$3, brand=$4, fuel_type=$5, engine_id=$6, price_minor=$7, price_currency=$8, updated_at=$9, version=version+1
+		WHERE ` + where + `
+		RETURNING id, name, year, brand, fuel_type, engine_id, price_minor, price_currency, created_at, updated_at, version
+	`
+	err = tx.QueryRowContext(ctx, query, args...).Scan(
 		&updateCar.ID,
 		&updateCar.Name,
 		&updateCar.Year,
 		&updateCar.Brand,
 		&updateCar.FuelType,
 		&updateCar.Engine.EngineId,
-		&updateCar.Price,
+		&updateCar.Price.AmountMinor,
+		&updateCar.Price.Currency,
 		&updateCar.CreatedAt,
 		&updateCar.UpdatedAt,
+		&updateCar.Version,
 	)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrModified(ctx, tx, id)
+			return models.Car{}, err
+		}
 		fmt.Println("Error updating car")
 		return models.Car{}, err
 	}
 
+	// a new price starts a new entry of the price history
+	if before != nil && before.Price != updateCar.Price {
+		err = recordPrice(ctx, tx, id, updateCar.Price, updatedAt)
+		if err != nil {
+			fmt.Println("Error recording the price")
+			return models.Car{}, err
+		}
+	}
+
+	err = record(ctx, tx, id, models.OperationUpdate, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Car{}, err
+	}
+
+	updateCar.Engine = engine
 	return updateCar, nil
 
 }
 
-func (s Store) DeleteCar(ctx context.Context, id string) (models.Car, error) {
+// DeleteCar soft deletes the car: it stays in the table with deleted_at set
+// until it is restored or purged. expectedVersion, when not zero, makes the
+// delete fail unless the car still has that version.
+func (s Store) DeleteCar(ctx context.Context, id string, expectedVersion int64) (_ models.Car, err error) {
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
 	// start transaction -> either all or none
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -265,54 +364,109 @@ func (s Store) DeleteCar(ctx context.Context, id string) (models.Car, error) {
 		err = tx.Commit()
 	}()
 
-	var deletedCar models.Car
+	returnQuery := selectCarWithEngine + `
+			WHERE
+				c.id = $1 AND c.deleted_at IS NULL;`
 
-	returnQuery := `
-		SELECT * from car WHERE id=$1;
-	`
-	err = tx.QueryRowContext(ctx, returnQuery,
-		id,
-	).Scan(
-		&deletedCar.ID,
-		&deletedCar.Name,
-		&deletedCar.Year,
-		&deletedCar.Brand,
-		&deletedCar.FuelType,
-		&deletedCar.Engine.EngineId,
-		&deletedCar.Price,
-		&deletedCar.CreatedAt,
-		&deletedCar.UpdatedAt,
-	)
+	deletedCar, err := scanCarWithEngine(tx.QueryRowContext(ctx, returnQuery, id))
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = apperrors.NewNotFound("car", id)
+			return models.Car{}, err
+		}
 		fmt.Println("Error while returning car values")
-		return models.Car{}, nil
+		return models.Car{}, err
+	}
+
+	if expectedVersion != 0 && deletedCar.Version != expectedVersion {
+		err = apperrors.NewPreconditionFailed("car", id)
+		return models.Car{}, err
+	}
+
+	// the car as it is before the delete, for the audit log
+	before, err := audit.CarState(ctx, tx, id)
+	if err != nil {
+		return models.Car{}, err
 	}
 
+	// only delete the version that was read above
+	deletedAt := time.Now()
 	deleteQuery := `
-		DELETE FROM car
-		WHERE id=$1
+		UPDATE car
+		SET deleted_at=$3, updated_at=$3, version=version+1
+		WHERE id=$1 AND version=$2 AND deleted_at IS NULL
 	`
 
 	result, err := tx.ExecContext(ctx, deleteQuery,
 		id,
+		deletedCar.Version,
+		deletedAt,
 	)
 
 	if err != nil {
 		fmt.Println("Error while deleting car")
-		return models.Car{}, nil
+		return models.Car{}, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		fmt.Println("Row affect error!")
-		return models.Car{}, nil
+		return models.Car{}, err
 	}
 
 	if rowsAffected == 0 {
-		println("Id do not exist!")
-		return models.Car{}, errors.New("id do not exist")
+		err = apperrors.NewPreconditionFailed("car", id)
+		return models.Car{}, err
 	}
 
+	err = record(ctx, tx, id, models.OperationDelete, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Car{}, err
+	}
+
+	deletedCar.DeletedAt = &deletedAt
+	deletedCar.UpdatedAt = deletedAt
+	deletedCar.Version++
 	return deletedCar, nil
 
 }
+
+// missingOrModified tells why a conditional write matched no row: the car
+// is gone, or it has another version than the one expected.
+func missingOrModified(ctx context.Context, tx *store.Tx, id string) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM car WHERE id=$1 AND deleted_at IS NULL`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.NewNotFound("car", id)
+	}
+	if err != nil {
+		return err
+	}
+	return apperrors.NewPreconditionFailed("car", id)
+}
+
+// engineById makes sure a car never points to a missing engine and
+// returns the engine to embed in the car. On postgres the engine row stays
+// share locked until tx ends, so it cannot be deleted before the car
+// written with it is committed.
+func engineById(ctx context.Context, tx *store.Tx, engineId uuid.UUID) (models.Engine, error) {
+	query := `SELECT id, displacement, no_of_cylinders, car_range, version from engine WHERE id=$1 AND deleted_at IS NULL`
+	if tx.Dialect() == store.Postgres {
+		query += " FOR SHARE"
+	}
+
+	var engine models.Engine
+	err := tx.QueryRowContext(ctx, query, engineId).Scan(
+		&engine.EngineId, &engine.Displacement, &engine.NoOfCylinders, &engine.CarRange, &engine.Version,
+	)
+	if err != nil {
+		// check if the err is no rows found err
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Engine{}, errEngineNotFound
+		}
+		fmt.Println("Error getting engine id")
+		return models.Engine{}, err
+	}
+	return engine, nil
+}
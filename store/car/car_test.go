@@ -0,0 +1,49 @@
+package car_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+func TestWriteCarChecksEngineSpecs(t *testing.T) {
+	ctx := context.Background()
+	cars, engines := newSQLiteStores(t)
+
+	engine, err := engines.CreateEngine(ctx, &models.EngineRequest{Displacement: 2000, NoOfCylinders: 4, CarRange: 600})
+	if err != nil {
+		t.Fatal(err)
+	}
+	carReq := models.CarRequest{
+		Name:     "Civic",
+		Year:     "2021",
+		Brand:    "Honda",
+		FuelType: "Petrol",
+		Engine:   engine,
+		Price:    models.Money{AmountMinor: 2500000, Currency: "USD"},
+	}
+	created, err := cars.CreateCar(ctx, carReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mismatched := carReq
+	mismatched.Engine.CarRange = 700
+
+	if _, err := cars.CreateCar(ctx, mismatched); !apperrors.IsValidation(err) {
+		t.Errorf("CreateCar() with the specs of another engine error = %v, want a validation error", err)
+	}
+	if _, err := cars.UpdateCar(ctx, created.ID.String(), &mismatched, 0); !apperrors.IsValidation(err) {
+		t.Errorf("UpdateCar() with the specs of another engine error = %v, want a validation error", err)
+	}
+
+	current, err := cars.GetCarById(ctx, created.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Version != created.Version {
+		t.Errorf("car version is %d after the rejected update, want %d", current.Version, created.Version)
+	}
+}
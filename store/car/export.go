@@ -0,0 +1,45 @@
+package car
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+// ExportCars calls each for every car matching the filter, in the order of
+// its sort keys, reading the rows one at a time from the database cursor
+// so the whole catalog is never held in memory. Limit and Cursor are
+// ignored. It stops at the first error returned by each.
+func (s Store) ExportCars(ctx context.Context, filter models.CarFilter, each func(models.Car) error) error {
+	where, args := carFilterConditions(filter)
+
+	// hide soft deleted cars unless asked for
+	if notDeleted := store.NotDeleted(ctx, "c"); notDeleted != "" {
+		where = append(where, notDeleted)
+	}
+
+	query := selectCarWithEngine
+	if len(where) > 0 {
+		query += "\n\t\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\t\tORDER BY " + carOrderBy(filter.Sort)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		car, err := scanCarWithEngine(rows)
+		if err != nil {
+			return err
+		}
+		if err := each(car); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,135 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	engineStore "github.com/TheMikeKaisen/CarManagement/store/engine"
+	"github.com/google/uuid"
+)
+
+// ImportCars creates the cars of rows, matching or creating their engines.
+// Rows that break a rule come back failed while the others go on. Dry runs
+// and atomic imports share one transaction that is rolled back at the end
+// of a dry run, or when a row of an atomic import failed; otherwise every
+// row commits on its own.
+func (s Store) ImportCars(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) ([]models.ImportResult, error) {
+	if !opts.DryRun && !opts.Atomic {
+		results := make([]models.ImportResult, 0, len(rows))
+		for _, row := range rows {
+			result, err := s.importRow(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Transaction Error")
+		return nil, err
+	}
+
+	results := make([]models.ImportResult, 0, len(rows))
+	failed := false
+	for _, row := range rows {
+		result, err := importRow(ctx, tx, row)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		failed = failed || result.Status == models.ImportFailed
+		results = append(results, result)
+	}
+
+	if opts.DryRun || failed {
+		return results, tx.Rollback()
+	}
+	return results, tx.Commit()
+}
+
+// importRow imports a single row in its own transaction.
+func (s Store) importRow(ctx context.Context, row models.ImportRow) (result models.ImportResult, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Transaction Error")
+		return models.ImportResult{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	return importRow(ctx, tx, row)
+}
+
+// importRow creates the car of row inside tx. A row breaking a rule is
+// reported in the result, only unexpected errors are returned.
+func importRow(ctx context.Context, tx *store.Tx, row models.ImportRow) (models.ImportResult, error) {
+	engine, engineCreated, err := importEngine(ctx, tx, row.Car.Engine)
+	if err != nil {
+		if apperrors.IsValidation(err) {
+			return models.NewImportFailure(row.Line, err), nil
+		}
+		return models.ImportResult{}, err
+	}
+
+	carReq := row.Car
+	carReq.Engine = engine
+	car, err := insertCar(ctx, tx, carReq)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	return models.ImportResult{
+		Line:          row.Line,
+		Status:        models.ImportCreated,
+		CarID:         &car.ID,
+		EngineID:      &engine.EngineId,
+		EngineCreated: engineCreated,
+	}, nil
+}
+
+// importEngine returns the engine with the id of spec, or else a live
+// engine with its specs, the first by id so the pick is stable, creating
+// one when there is none. The bool tells whether the engine was created.
+func importEngine(ctx context.Context, tx *store.Tx, spec models.Engine) (models.Engine, bool, error) {
+	if spec.EngineId != uuid.Nil {
+		engine, err := engineById(ctx, tx, spec.EngineId)
+		return engine, false, err
+	}
+
+	var engine models.Engine
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, displacement, no_of_cylinders, car_range, version
+		FROM engine
+		WHERE displacement=$1 AND no_of_cylinders=$2 AND car_range=$3 AND deleted_at IS NULL
+		ORDER BY id
+		LIMIT 1`,
+		spec.Displacement, spec.NoOfCylinders, spec.CarRange,
+	).Scan(&engine.EngineId, &engine.Displacement, &engine.NoOfCylinders, &engine.CarRange, &engine.Version)
+	if err == nil {
+		return engine, false, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		fmt.Println("Error matching the engine")
+		return models.Engine{}, false, err
+	}
+
+	engine, err = engineStore.Insert(ctx, tx, &models.EngineRequest{
+		Displacement:  spec.Displacement,
+		NoOfCylinders: spec.NoOfCylinders,
+		CarRange:      spec.CarRange,
+	})
+	return engine, true, err
+}
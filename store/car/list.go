@@ -0,0 +1,214 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+// sql expression behind every sortable field. Engine columns are
+// coalesced so cars without an engine still have a position.
+var carSortColumns = map[string]string{
+	"name":            "c.name",
+	"year":            "c.year",
+	"brand":           "c.brand",
+	"fuel_type":       "c.fuel_type",
+	"price":           "c.price_minor",
+	"created_at":      "c.created_at",
+	"updated_at":      "c.updated_at",
+	"displacement":    "COALESCE(e.displacement, 0)",
+	"no_of_cylinders": "COALESCE(e.no_of_cylinders, 0)",
+	"car_range":       "COALESCE(e.car_range, 0)",
+}
+
+const selectCarWithEngine = `SELECT
+				c.id, c.name, c.year, c.brand, c.fuel_type, c.price_minor, c.price_currency, c.created_at, c.updated_at, c.version, c.deleted_at,
+				e.id AS engine_id, e.displacement, e.no_of_cylinders, e.car_range, e.version
+			FROM
+				car c
+			LEFT JOIN
+				engine e ON c.engine_id = e.id`
+
+// ListCars returns one page of cars matching the filter, ordered by the
+// requested sort keys and paginated with a keyset cursor.
+func (s Store) ListCars(ctx context.Context, filter models.CarFilter) (models.CarPage, error) {
+	where, args := carFilterConditions(filter)
+
+	// hide soft deleted cars unless asked for
+	if notDeleted := store.NotDeleted(ctx, "c"); notDeleted != "" {
+		where = append(where, notDeleted)
+	}
+
+	// resume after the cursor position
+	sortSpec := models.SortSpec(filter.Sort)
+	if filter.Cursor != "" {
+		cursor, err := store.DecodeCursor(filter.Cursor, sortSpec, len(filter.Sort))
+		if err != nil {
+			return models.CarPage{}, err
+		}
+
+		exprs := make([]string, len(filter.Sort))
+		desc := make([]bool, len(filter.Sort))
+		values := make([]any, len(filter.Sort))
+		for i, sort := range filter.Sort {
+			exprs[i] = carSortColumns[sort.Field]
+			desc[i] = sort.Desc
+			values[i], err = store.CursorValue(cursor.Values[i], models.CarSortValue(models.Car{}, sort.Field))
+			if err != nil {
+				return models.CarPage{}, err
+			}
+		}
+
+		condition, keysetArgs := store.KeysetCondition(exprs, desc, values, "c.id", cursor.ID, len(args)+1)
+		where = append(where, condition)
+		args = append(args, keysetArgs...)
+	}
+
+	query := selectCarWithEngine
+	if len(where) > 0 {
+		query += "\n\t\t\tWHERE " + strings.Join(where, " AND ")
+	}
+	query += "\n\t\t\tORDER BY " + carOrderBy(filter.Sort)
+	// fetch one extra row to know whether there is a next page
+	query += "\n\t\t\tLIMIT " + strconv.Itoa(filter.Limit+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+	defer rows.Close()
+
+	cars := []models.Car{}
+	for rows.Next() {
+		car, err := scanCarWithEngine(rows)
+		if err != nil {
+			return models.CarPage{}, err
+		}
+		cars = append(cars, car)
+	}
+	if err := rows.Err(); err != nil {
+		return models.CarPage{}, err
+	}
+
+	page := models.CarPage{Cars: cars}
+	if len(cars) > filter.Limit {
+		page.Cars = cars[:filter.Limit]
+		page.NextCursor = nextCarCursor(page.Cars[len(page.Cars)-1], filter.Sort)
+	}
+
+	return page, nil
+}
+
+func carFilterConditions(filter models.CarFilter) ([]string, []any) {
+	var where []string
+	var args []any
+
+	add := func(condition string, arg any) {
+		args = append(args, arg)
+		where = append(where, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.Brand != "" {
+		add("c.brand = ?", filter.Brand)
+	}
+	if filter.FuelType != "" {
+		add("c.fuel_type = ?", filter.FuelType)
+	}
+	// years are stored as four digit text, so text comparison keeps their order
+	if filter.MinYear != nil {
+		add("c.year >= ?", strconv.Itoa(*filter.MinYear))
+	}
+	if filter.MaxYear != nil {
+		add("c.year <= ?", strconv.Itoa(*filter.MaxYear))
+	}
+	// the service has checked the bounds are in the price currency
+	if filter.PriceCurrency != "" {
+		add("c.price_currency = ?", filter.PriceCurrency)
+	}
+	if filter.MinPrice != nil {
+		add("c.price_minor >= ?", filter.MinPrice.AmountMinor)
+	}
+	if filter.MaxPrice != nil {
+		add("c.price_minor <= ?", filter.MaxPrice.AmountMinor)
+	}
+	if filter.MinDisplacement != nil {
+		add("e.displacement >= ?", *filter.MinDisplacement)
+	}
+	if filter.MaxDisplacement != nil {
+		add("e.displacement <= ?", *filter.MaxDisplacement)
+	}
+	if filter.MinNoOfCylinders != nil {
+		add("e.no_of_cylinders >= ?", *filter.MinNoOfCylinders)
+	}
+	if filter.MaxNoOfCylinders != nil {
+		add("e.no_of_cylinders <= ?", *filter.MaxNoOfCylinders)
+	}
+	if filter.MinCarRange != nil {
+		add("e.car_range >= ?", *filter.MinCarRange)
+	}
+	if filter.MaxCarRange != nil {
+		add("e.car_range <= ?", *filter.MaxCarRange)
+	}
+
+	return where, args
+}
+
+func carOrderBy(sort []models.SortField) string {
+	parts := make([]string, 0, len(sort)+1)
+	for _, field := range sort {
+		direction := " ASC"
+		if field.Desc {
+			direction = " DESC"
+		}
+		parts = append(parts, carSortColumns[field.Field]+direction)
+	}
+	// the id keeps the order stable between equal rows
+	parts = append(parts, "c.id ASC")
+	return strings.Join(parts, ", ")
+}
+
+func nextCarCursor(last models.Car, sort []models.SortField) string {
+	values := make([]any, len(sort))
+	for i, field := range sort {
+		values[i] = models.CarSortValue(last, field.Field)
+	}
+	return store.Cursor{Sort: models.SortSpec(sort), Values: values, ID: last.ID.String()}.Encode()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCarWithEngine reads a row of selectCarWithEngine. The engine columns
+// come from a LEFT JOIN and may be NULL, like deleted_at of a live car.
+func scanCarWithEngine(row scanner) (models.Car, error) {
+	var car models.Car
+	var engineId uuid.NullUUID
+	var displacement, noOfCylinders, carRange, engineVersion sql.NullInt64
+	var deletedAt sql.NullTime
+
+	err := row.Scan(
+		&car.ID, &car.Name, &car.Year, &car.Brand, &car.FuelType, &car.Price.AmountMinor, &car.Price.Currency, &car.CreatedAt, &car.UpdatedAt, &car.Version, &deletedAt,
+		&engineId, &displacement, &noOfCylinders, &carRange, &engineVersion,
+	)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	car.Engine = models.Engine{
+		EngineId:      engineId.UUID,
+		Displacement:  displacement.Int64,
+		NoOfCylinders: noOfCylinders.Int64,
+		CarRange:      carRange.Int64,
+		Version:       engineVersion.Int64,
+	}
+	if deletedAt.Valid {
+		car.DeletedAt = &deletedAt.Time
+	}
+	return car, nil
+}
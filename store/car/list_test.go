@@ -0,0 +1,145 @@
+package car_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/TheMikeKaisen/CarManagement/driver"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/car"
+	"github.com/TheMikeKaisen/CarManagement/store/engine"
+	"github.com/TheMikeKaisen/CarManagement/store/migrations"
+)
+
+// newSQLiteStores opens a migrated sqlite database in a temporary directory.
+func newSQLiteStores(t *testing.T) (car.Store, engine.Engine) {
+	t.Helper()
+	ctx := context.Background()
+
+	sqlDB, err := driver.ConnectSQLite(ctx, filepath.Join(t.TempDir(), "cars.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db := store.NewDB(sqlDB, store.SQLite)
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	return car.New(db), engine.New(db)
+}
+
+// seedCars creates cars sharing some of their sort values, so the pages
+// also have to break ties, updates some of them so updated_at and
+// created_at differ in order, and detaches the engine of one.
+func seedCars(t *testing.T, cars car.Store, engines engine.Engine) {
+	t.Helper()
+	ctx := context.Background()
+
+	var specs []models.Engine
+	for _, req := range []models.EngineRequest{
+		{Displacement: 1600, NoOfCylinders: 4, CarRange: 500},
+		{Displacement: 3000, NoOfCylinders: 6, CarRange: 400},
+		{Displacement: 1600, NoOfCylinders: 4, CarRange: 700},
+	} {
+		created, err := engines.CreateEngine(ctx, &req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		specs = append(specs, created)
+	}
+
+	brands := []string{"Honda", "BMW", "Honda", "Audi"}
+	fuelTypes := []string{"Petrol", "Diesel", "Electric"}
+	var created []models.Car
+	for i := 0; i < 9; i++ {
+		carReq := models.CarRequest{
+			Name:     "car " + string(rune('a'+i%5)),
+			Year:     []string{"2019", "2021", "2020"}[i%3],
+			Brand:    brands[i%len(brands)],
+			FuelType: fuelTypes[i%len(fuelTypes)],
+			Engine:   specs[i%len(specs)],
+			Price:    models.Money{AmountMinor: int64(1000000 + 250000*(i%4)), Currency: "USD"},
+		}
+		newCar, err := cars.CreateCar(ctx, carReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		created = append(created, newCar)
+	}
+
+	for _, i := range []int{4, 1, 7} {
+		carReq := models.CarRequest{
+			Name:     created[i].Name,
+			Year:     created[i].Year,
+			Brand:    created[i].Brand,
+			FuelType: created[i].FuelType,
+			Engine:   created[i].Engine,
+			Price:    models.Money{AmountMinor: created[i].Price.AmountMinor + 1, Currency: "USD"},
+		}
+		if _, err := cars.UpdateCar(ctx, created[i].ID.String(), &carReq, created[i].Version); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := engines.DeleteEngine(ctx, specs[2].EngineId.String(), models.CascadeDetach, specs[2].Version); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListCarsWalksEveryPage(t *testing.T) {
+	ctx := context.Background()
+	cars, engines := newSQLiteStores(t)
+	seedCars(t, cars, engines)
+
+	for _, field := range models.CarSortFields {
+		for _, desc := range []bool{false, true} {
+			sort := []models.SortField{{Field: field, Desc: desc}}
+			t.Run(models.SortSpec(sort), func(t *testing.T) {
+				filter := models.CarFilter{Sort: sort, PriceCurrency: "USD"}
+
+				filter.Limit = models.MaxPageSize
+				all, err := cars.ListCars(ctx, filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(all.Cars) != 9 || all.NextCursor != "" {
+					t.Fatalf("listed %d cars in one page, next cursor %q", len(all.Cars), all.NextCursor)
+				}
+
+				var walked []models.Car
+				filter.Limit = 2
+				for pages := 0; ; pages++ {
+					if pages > len(all.Cars) {
+						t.Fatalf("still paging after %d pages, the cursor does not advance", pages)
+					}
+					page, err := cars.ListCars(ctx, filter)
+					if err != nil {
+						t.Fatal(err)
+					}
+					walked = append(walked, page.Cars...)
+					if page.NextCursor == "" {
+						break
+					}
+					filter.Cursor = page.NextCursor
+				}
+
+				if len(walked) != len(all.Cars) {
+					t.Fatalf("walked %d cars, want %d", len(walked), len(all.Cars))
+				}
+				for i := range walked {
+					if walked[i].ID != all.Cars[i].ID {
+						t.Fatalf("car %d of the pages is %s, want %s", i, walked[i].ID, all.Cars[i].ID)
+					}
+				}
+			})
+		}
+	}
+}
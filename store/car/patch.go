@@ -0,0 +1,133 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// PatchCar updates only the columns set in patch and returns the car with
+// its engine as it is after the update. expectedVersion, when not zero,
+// makes the update fail unless the car still has that version.
+func (s Store) PatchCar(ctx context.Context, id string, patch models.CarPatch, expectedVersion int64) (_ models.Car, err error) {
+
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	var set []string
+	args := []any{id}
+
+	add := func(column string, arg any) {
+		args = append(args, arg)
+		set = append(set, column+"=$"+strconv.Itoa(len(args)))
+	}
+
+	if patch.Name != nil {
+		add("name", *patch.Name)
+	}
+	if patch.Year != nil {
+		add("year", *patch.Year)
+	}
+	if patch.Brand != nil {
+		add("brand", *patch.Brand)
+	}
+	if patch.FuelType != nil {
+		add("fuel_type", *patch.FuelType)
+	}
+	if patch.EngineId != nil {
+		add("engine_id", *patch.EngineId)
+	}
+	if patch.Price != nil {
+		add("price_minor", patch.Price.AmountMinor)
+		add("price_currency", patch.Price.Currency)
+	}
+	updatedAt := time.Now()
+	add("updated_at", updatedAt)
+	set = append(set, "version=version+1")
+
+	where := "id=$1 AND deleted_at IS NULL"
+	if expectedVersion != 0 {
+		args = append(args, expectedVersion)
+		where += " AND version=$" + strconv.Itoa(len(args))
+	}
+
+	// use transaction so the car is read back as it was written
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// a new engine has to exist
+	if patch.EngineId != nil {
+		if _, err := engineById(ctx, tx, *patch.EngineId); err != nil {
+			return models.Car{}, err
+		}
+	}
+
+	// the car as it is before the update, for the audit log
+	before, err := audit.CarState(ctx, tx, id)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE car SET `+strings.Join(set, ", ")+` WHERE `+where, args...)
+	if err != nil {
+		fmt.Println("Error patching car")
+		return models.Car{}, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return models.Car{}, err
+	}
+	if rowsAffected == 0 {
+		err = missingOrModified(ctx, tx, id)
+		return models.Car{}, err
+	}
+
+	// a new price starts a new entry of the price history
+	if patch.Price != nil && before != nil && before.Price != *patch.Price {
+		err = recordPrice(ctx, tx, id, *patch.Price, updatedAt)
+		if err != nil {
+			fmt.Println("Error recording the price")
+			return models.Car{}, err
+		}
+	}
+
+	err = record(ctx, tx, id, models.OperationUpdate, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Car{}, err
+	}
+
+	patchedCar, err := scanCarWithEngine(tx.QueryRowContext(ctx, selectCarWithEngine+`
+			WHERE
+				c.id = $1;`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = apperrors.NewNotFound("car", id)
+		}
+		return models.Car{}, err
+	}
+
+	return patchedCar, nil
+}
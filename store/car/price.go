@@ -0,0 +1,129 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+// CarPrices returns every price the car has had, oldest first.
+func (s Store) CarPrices(ctx context.Context, id string) ([]models.PricePoint, error) {
+
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewInvalidID(id, err)
+	}
+
+	// the car has to exist, soft deleted cars only when asked for
+	existsQuery := `SELECT 1 FROM car WHERE id=$1`
+	if notDeleted := store.NotDeleted(ctx, ""); notDeleted != "" {
+		existsQuery += " AND " + notDeleted
+	}
+	var exists int
+	err := s.db.QueryRowContext(ctx, existsQuery, id).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound("car", id)
+		}
+		return nil, err
+	}
+
+	query := `
+		SELECT price_minor, price_currency, effective_from, effective_to
+		FROM car_price
+		WHERE car_id = $1
+		ORDER BY effective_from, id
+	`
+	rows, err := s.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prices := []models.PricePoint{}
+	for rows.Next() {
+		var point models.PricePoint
+		var effectiveTo sql.NullTime
+		if err := rows.Scan(&point.Price.AmountMinor, &point.Price.Currency, &point.EffectiveFrom, &effectiveTo); err != nil {
+			return nil, err
+		}
+		if effectiveTo.Valid {
+			point.EffectiveTo = &effectiveTo.Time
+		}
+		prices = append(prices, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		fmt.Println("Error while querying rows.")
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// PriceStats aggregates the current prices of the live cars per brand,
+// fuel type or year, and per currency since prices in different currencies
+// cannot be added up.
+func (s Store) PriceStats(ctx context.Context, groupBy string) ([]models.PriceStats, error) {
+	var column string
+	switch groupBy {
+	case "brand", "fuel_type", "year":
+		column = groupBy
+	default:
+		return nil, models.ValidatePriceGroupBy(groupBy)
+	}
+
+	query := `
+		SELECT ` + column + `, price_currency, COUNT(*), ROUND(AVG(price_minor)), MIN(price_minor), MAX(price_minor)
+		FROM car
+		WHERE deleted_at IS NULL
+		GROUP BY ` + column + `, price_currency
+		ORDER BY ` + column + `, price_currency`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.PriceStats{}
+	for rows.Next() {
+		var group models.PriceStats
+		var average float64
+		err := rows.Scan(&group.Group, &group.Currency, &group.Count, &average, &group.Min.AmountMinor, &group.Max.AmountMinor)
+		if err != nil {
+			return nil, err
+		}
+
+		group.Average = models.Money{AmountMinor: int64(average), Currency: group.Currency}
+		group.Min.Currency = group.Currency
+		group.Max.Currency = group.Currency
+		stats = append(stats, group)
+	}
+
+	if err := rows.Err(); err != nil {
+		fmt.Println("Error while querying rows.")
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// recordPrice closes the current price of the car and opens a new one at
+// the given time, inside the transaction changing car.price.
+func recordPrice(ctx context.Context, tx *store.Tx, carId string, price models.Money, at time.Time) error {
+	_, err := tx.ExecContext(ctx, `UPDATE car_price SET effective_to=$2 WHERE car_id=$1 AND effective_to IS NULL`, carId, at)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO car_price (car_id, price_minor, price_currency, effective_from) VALUES ($1, $2, $3, $4)`, carId, price.AmountMinor, price.Currency, at)
+	return err
+}
@@ -0,0 +1,160 @@
+package car
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// RestoreCar brings a soft deleted car back. Its engine has to be live,
+// so an engine deleted in the meantime must be restored first.
+func (s Store) RestoreCar(ctx context.Context, id string) (_ models.Car, err error) {
+
+	// parse string id into uuid.UUID
+	if _, err := uuid.Parse(id); err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Error while starting transaction!")
+		return models.Car{}, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	query := selectCarWithEngine + `
+			WHERE
+				c.id = $1;`
+
+	car, err := scanCarWithEngine(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = apperrors.NewNotFound("car", id)
+		}
+		return models.Car{}, err
+	}
+
+	if car.DeletedAt == nil {
+		err = apperrors.NewConflict("car is not deleted")
+		return models.Car{}, err
+	}
+
+	// a detached car has no engine to check
+	if car.Engine.EngineId != uuid.Nil {
+		var engineDeletedAt sql.NullTime
+		err = tx.QueryRowContext(ctx, `SELECT deleted_at FROM engine WHERE id=$1`, car.Engine.EngineId).Scan(&engineDeletedAt)
+		if err != nil {
+			return models.Car{}, err
+		}
+		if engineDeletedAt.Valid {
+			conflict := apperrors.NewConflict("the engine of the car is deleted, restore it first")
+			conflict.Details = map[string]any{"engine_id": car.Engine.EngineId.String()}
+			err = conflict
+			return models.Car{}, err
+		}
+	}
+
+	// the car as it is before the restore, for the audit log
+	before, err := audit.CarState(ctx, tx, id)
+	if err != nil {
+		return models.Car{}, err
+	}
+
+	restoredAt := time.Now()
+	_, err = tx.ExecContext(ctx, `UPDATE car SET deleted_at=NULL, updated_at=$2, version=version+1 WHERE id=$1`, id, restoredAt)
+	if err != nil {
+		fmt.Println("Error while restoring car")
+		return models.Car{}, err
+	}
+
+	err = record(ctx, tx, id, models.OperationRestore, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Car{}, err
+	}
+
+	car.DeletedAt = nil
+	car.UpdatedAt = restoredAt
+	car.Version++
+	return car, nil
+}
+
+// PurgeCars hard deletes the cars soft deleted before the given time and
+// returns how many were removed. Every purged car is audited with its last
+// state, so its history outlives it.
+func (s Store) PurgeCars(ctx context.Context, before time.Time) (_ int64, err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Error while starting transaction!")
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	carIds, err := purgeableCars(ctx, tx, before)
+	if err != nil {
+		fmt.Println("Error while looking for cars to purge")
+		return 0, err
+	}
+
+	for _, carId := range carIds {
+		var state *models.CarState
+		state, err = audit.CarState(ctx, tx, carId)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM car WHERE id=$1`, carId)
+		if err != nil {
+			fmt.Println("Error while purging cars")
+			return 0, err
+		}
+
+		err = record(ctx, tx, carId, models.OperationPurge, state)
+		if err != nil {
+			fmt.Println("Error recording the audit entry")
+			return 0, err
+		}
+	}
+
+	return int64(len(carIds)), nil
+}
+
+func purgeableCars(ctx context.Context, tx *store.Tx, before time.Time) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM car WHERE deleted_at IS NOT NULL AND deleted_at < $1 ORDER BY id`, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carIds := []string{}
+	for rows.Next() {
+		var carId string
+		if err := rows.Scan(&carId); err != nil {
+			return nil, err
+		}
+		carIds = append(carIds, carId)
+	}
+	return carIds, rows.Err()
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// Cursor marks the position after the last row of a page for keyset
+// pagination: the values of the sort keys of that row plus its id as the
+// final tie breaker. Sort records the sort spec the cursor was made for.
+type Cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+	ID     string `json:"id"`
+}
+
+// Encode turns the cursor into an opaque url-safe token.
+func (c Cursor) Encode() string {
+	body, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+// DecodeCursor parses a token made by Cursor.Encode and checks that it was
+// issued for the same sort spec. Numbers are kept as json.Number so the
+// caller can convert them to the type of their column.
+func DecodeCursor(token string, sort string, keys int) (Cursor, error) {
+	invalid := apperrors.NewBadRequest("invalid cursor", nil)
+
+	body, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, invalid
+	}
+
+	var cursor Cursor
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cursor); err != nil {
+		return Cursor{}, invalid
+	}
+
+	if cursor.Sort != sort || len(cursor.Values) != keys || cursor.ID == "" {
+		return Cursor{}, apperrors.NewBadRequest("cursor does not match the requested sort", nil)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Dialect identifies the sql flavour spoken by the database.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// Rebind rewrites the postgres style $n placeholders used by the stores
+// into the placeholder style of the dialect. SQLite understands ?n, which
+// keeps the numbering so a parameter can still be referenced twice.
+func (d Dialect) Rebind(query string) string {
+	if d != SQLite {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		if query[i] == '$' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteByte(query[i])
+	}
+	return b.String()
+}
+
+// Bind prepares the args of a query for the dialect. SQLite keeps times as
+// text, which only compares like the times themselves when every one of
+// them is written in the same zone, so they are all bound in UTC.
+func (d Dialect) Bind(args []any) []any {
+	if d != SQLite {
+		return args
+	}
+
+	bound := make([]any, len(args))
+	for i, arg := range args {
+		switch value := arg.(type) {
+		case time.Time:
+			bound[i] = value.UTC()
+		case *time.Time:
+			if value != nil {
+				bound[i] = value.UTC()
+			}
+		default:
+			bound[i] = arg
+		}
+	}
+	return bound
+}
+
+// Querier is implemented by both DB and Tx, for helpers that run either
+// on their own or as part of a bigger transaction.
+type Querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// DB wraps *sql.DB so every query written for postgres is rebound for the
+// configured dialect before it reaches the driver.
+type DB struct {
+	*sql.DB
+	Dialect Dialect
+}
+
+func NewDB(db *sql.DB, dialect Dialect) *DB {
+	return &DB{DB: db, Dialect: dialect}
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.DB.ExecContext(ctx, db.Dialect.Rebind(query), db.Dialect.Bind(args)...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.DB.QueryContext(ctx, db.Dialect.Rebind(query), db.Dialect.Bind(args)...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.DB.QueryRowContext(ctx, db.Dialect.Rebind(query), db.Dialect.Bind(args)...)
+}
+
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, dialect: db.Dialect}, nil
+}
+
+// Tx is the transactional counterpart of DB.
+type Tx struct {
+	*sql.Tx
+	dialect Dialect
+}
+
+func (tx *Tx) Dialect() Dialect {
+	return tx.dialect
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.Tx.ExecContext(ctx, tx.dialect.Rebind(query), tx.dialect.Bind(args)...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.Tx.QueryContext(ctx, tx.dialect.Rebind(query), tx.dialect.Bind(args)...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return tx.Tx.QueryRowContext(ctx, tx.dialect.Rebind(query), tx.dialect.Bind(args)...)
+}
+
+// IsForeignKeyViolation reports whether err was raised because a row is
+// still referenced by, or references a missing, row of another table.
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == "23503"
+	}
+	return err != nil && strings.Contains(err.Error(), "FOREIGN KEY constraint failed")
+}
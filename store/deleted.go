@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+)
+
+type includeDeletedKey struct{}
+
+// WithDeleted marks ctx so store reads also return soft deleted records.
+func WithDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// IncludeDeleted reports whether reads made with ctx show soft deleted records.
+func IncludeDeleted(ctx context.Context) bool {
+	include, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return include
+}
+
+// NotDeleted returns the condition that hides the tombstones of table
+// alias, or "" when ctx asks for deleted records too.
+func NotDeleted(ctx context.Context, alias string) string {
+	if IncludeDeleted(ctx) {
+		return ""
+	}
+	if alias == "" {
+		return "deleted_at IS NULL"
+	}
+	return alias + ".deleted_at IS NULL"
+}
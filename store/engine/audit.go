@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// History returns the audit trail of an engine or a car, oldest first.
+func (e Engine) History(ctx context.Context, entity string, id string) ([]models.AuditEntry, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewInvalidID(id, err)
+	}
+	return audit.History(ctx, e.db, entity, id)
+}
+
+// record appends the audit entry of a write to the engine inside tx,
+// reading its state after the write.
+func record(ctx context.Context, tx *store.Tx, id uuid.UUID, operation string, before *models.Engine) error {
+	after, err := audit.EngineState(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return audit.Record(ctx, tx, models.EntityEngine, id.String(), operation, before, after)
+}
+
+// carStates reads the cars changed by a cascading delete, for their audit
+// entries.
+func carStates(ctx context.Context, tx *store.Tx, carIds []string) ([]*models.CarState, error) {
+	states := make([]*models.CarState, 0, len(carIds))
+	for _, carId := range carIds {
+		state, err := audit.CarState(ctx, tx, carId)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// recordCars audits the cars changed by a cascading delete.
+func recordCars(ctx context.Context, tx *store.Tx, carIds []string, operation string, before []*models.CarState) error {
+	after, err := carStates(ctx, tx, carIds)
+	if err != nil {
+		return err
+	}
+
+	for i, carId := range carIds {
+		err := audit.Record(ctx, tx, models.EntityCar, carId, operation, before[i], after[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
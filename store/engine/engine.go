@@ -5,26 +5,32 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
 	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
 	"github.com/google/uuid"
 )
 
 type Engine struct {
-	db *sql.DB
+	db *store.DB
 }
 
-func new(db *sql.DB) Engine {
+func New(db *store.DB) Engine {
 	return Engine{db: db}
 }
 
-func (e Engine) CreateEngine(ctx context.Context, engineReq models.EngineRequest) (models.Engine, error) {
+func (e Engine) CreateEngine(ctx context.Context, engineReq *models.EngineRequest) (_ models.Engine, err error) {
 
 	// start transaction -> either all or none!
 	tx, err := e.db.BeginTx(ctx, nil)
 	if err != nil {
 		fmt.Println("Error while starting transaction")
-		return models.Engine{}, nil
+		return models.Engine{}, err
 	}
 	defer func() {
 		if err != nil {
@@ -34,6 +40,19 @@ func (e Engine) CreateEngine(ctx context.Context, engineReq models.EngineRequest
 		err = tx.Commit()
 	}()
 
+	createdEngine, err := Insert(ctx, tx, engineReq)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	return createdEngine, nil
+
+}
+
+// Insert creates an engine and its audit entry inside tx, for writes that
+// create engines as part of a bigger transaction.
+func Insert(ctx context.Context, tx *store.Tx, engineReq *models.EngineRequest) (models.Engine, error) {
+
 	// to store engine
 	var createdEngine models.Engine
 
@@ -43,10 +62,10 @@ func (e Engine) CreateEngine(ctx context.Context, engineReq models.EngineRequest
 	query := `
 		INSERT INTO engine(id, displacement, no_of_cylinders, car_range)
 		VALUES($1, $2, $3, $4)
-		RETURNING id, displacement, no_of_cylinders, car_range
+		RETURNING id, displacement, no_of_cylinders, car_range, version
 	`
 
-	err = tx.QueryRowContext(ctx, query,
+	err := tx.QueryRowContext(ctx, query,
 		engineId,
 		engineReq.Displacement,
 		engineReq.NoOfCylinders,
@@ -56,25 +75,30 @@ func (e Engine) CreateEngine(ctx context.Context, engineReq models.EngineRequest
 		&createdEngine.Displacement,
 		&createdEngine.NoOfCylinders,
 		&createdEngine.CarRange,
+		&createdEngine.Version,
 	)
 
 	if err != nil {
 		fmt.Println("Error while creating an engine")
-		return models.Engine{}, nil
+		return models.Engine{}, err
 	}
 
-	return createdEngine, nil
+	// audit the create in the same transaction
+	err = record(ctx, tx, engineId, models.OperationCreate, nil)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Engine{}, err
+	}
 
+	return createdEngine, nil
 }
 
 func (e Engine) GetEngineById(ctx context.Context, engineId string) (models.Engine, error) {
 
-	
 	// parse string id into uuid.UUID
 	id, err := uuid.Parse(engineId)
 	if err != nil {
-		fmt.Println("error while parsing id")
-		return models.Engine{}, err
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
 	}
 
 	// to store engine
@@ -82,42 +106,99 @@ func (e Engine) GetEngineById(ctx context.Context, engineId string) (models.Engi
 
 	// query
 	getEngineQuery := `
-		SELECT id, displacement, no_of_cylinders, car_range
+		SELECT id, displacement, no_of_cylinders, car_range, version, deleted_at
 		from engine
 		WHERE id=$1
 	`
+	if notDeleted := store.NotDeleted(ctx, ""); notDeleted != "" {
+		getEngineQuery += " AND " + notDeleted
+	}
 
+	var deletedAt sql.NullTime
 	err = e.db.QueryRowContext(ctx, getEngineQuery, id).Scan(
 		&getEngine.EngineId,
 		&getEngine.Displacement,
 		&getEngine.NoOfCylinders,
 		&getEngine.CarRange,
+		&getEngine.Version,
+		&deletedAt,
 	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			fmt.Println("No engine with the given id")
-			return models.Engine{}, errors.New("no engine with the given id")
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Engine{}, apperrors.NewNotFound("engine", engineId)
 		}
 		fmt.Println("Error while getting engine")
 		return models.Engine{}, err
 	}
+	if deletedAt.Valid {
+		getEngine.DeletedAt = &deletedAt.Time
+	}
 	return getEngine, nil
 }
 
-func (e Engine) UpdateEngine(ctx context.Context, engineId string, engineReq models.EngineRequest) (models.Engine, error) {
+// GetEnginesByIds reads the engines in one query, for callers that need
+// the engines of many cars. Engines that are not found are left out.
+func (e Engine) GetEnginesByIds(ctx context.Context, engineIds []string) ([]models.Engine, error) {
+	engines := []models.Engine{}
+	if len(engineIds) == 0 {
+		return engines, nil
+	}
+
+	args := make([]any, len(engineIds))
+	placeholders := make([]string, len(engineIds))
+	for i, engineId := range engineIds {
+		id, err := uuid.Parse(engineId)
+		if err != nil {
+			return nil, apperrors.NewInvalidID(engineId, err)
+		}
+		args[i] = id
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+	}
+
+	query := `
+		SELECT id, displacement, no_of_cylinders, car_range, version, deleted_at
+		FROM engine
+		WHERE id IN (` + strings.Join(placeholders, ", ") + `)`
+	if notDeleted := store.NotDeleted(ctx, ""); notDeleted != "" {
+		query += " AND " + notDeleted
+	}
+
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var engine models.Engine
+		var deletedAt sql.NullTime
+		err := rows.Scan(&engine.EngineId, &engine.Displacement, &engine.NoOfCylinders, &engine.CarRange, &engine.Version, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		if deletedAt.Valid {
+			engine.DeletedAt = &deletedAt.Time
+		}
+		engines = append(engines, engine)
+	}
+	return engines, rows.Err()
+}
+
+// UpdateEngine replaces the engine specs. expectedVersion, when not zero,
+// makes the update fail unless the engine still has that version.
+func (e Engine) UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest, expectedVersion int64) (_ models.Engine, err error) {
 
 	// parse string id into uuid.UUID
 	id, err := uuid.Parse(engineId)
 	if err != nil {
-		fmt.Println("error while parsing id")
-		return models.Engine{}, err
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
 	}
 
 	// start transaction
 	tx, err := e.db.BeginTx(ctx, nil)
 	if err != nil {
 		fmt.Println("Error while starting transaction")
-		return models.Engine{}, nil
+		return models.Engine{}, err
 	}
 	defer func() {
 		if err != nil {
@@ -127,51 +208,79 @@ func (e Engine) UpdateEngine(ctx context.Context, engineId string, engineReq mod
 		err = tx.Commit()
 	}()
 
+	// the engine as it is before the update, for the audit log
+	before, err := audit.EngineState(ctx, tx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
 	// store updated engine
 	var updatedEngine models.Engine
 
+	args := []any{
+		id,
+		engineReq.Displacement,
+		engineReq.NoOfCylinders,
+		engineReq.CarRange,
+	}
+
+	where := "id=$1 AND deleted_at IS NULL"
+	if expectedVersion != 0 {
+		args = append(args, expectedVersion)
+		where += " AND version=$5"
+	}
+
 	updateEngineQuery := `
 		UPDATE engine
-		SET displacement=$2, no_of_cylinders=$3, car_range=$4
-		WHERE id=$1
-		RETURNING id, displacement, no_of_cylinders, car_range
+		SET displacement=$2, no_of_cylinders=$3, car_range=$4, version=version+1
+		WHERE ` + where + `
+		RETURNING id, displacement, no_of_cylinders, car_range, version
 	`
-	err = tx.QueryRowContext(ctx, updateEngineQuery, 
-		id, 
-		engineReq.Displacement, 
-		engineReq.NoOfCylinders, 
-		engineReq.CarRange,
-	).Scan(
+	err = tx.QueryRowContext(ctx, updateEngineQuery, args...).Scan(
 		&updatedEngine.EngineId,
 		&updatedEngine.Displacement,
 		&updatedEngine.NoOfCylinders,
 		&updatedEngine.CarRange,
+		&updatedEngine.Version,
 	)
 
-	if err!= nil {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrModified(ctx, tx, id)
+			return models.Engine{}, err
+		}
 		fmt.Println("Error while updating engine")
 		return models.Engine{}, err
 	}
 
-	return updatedEngine, nil
+	err = record(ctx, tx, id, models.OperationUpdate, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Engine{}, err
+	}
 
+	return updatedEngine, nil
 
 }
 
-func (e Engine) DeleteEngine(ctx context.Context, engineId string)(models.Engine, error) {
+// DeleteEngine soft deletes the engine. Cars still using it make the delete
+// fail with a conflict listing their ids, unless cascade says to detach
+// them (engine_id set to NULL) or soft delete them along with the engine.
+// expectedVersion, when not zero, makes the delete fail unless the engine
+// still has that version.
+func (e Engine) DeleteEngine(ctx context.Context, engineId string, cascade models.CascadeMode, expectedVersion int64) (_ models.Engine, err error) {
 
 	// parse string id into uuid.UUID
 	id, err := uuid.Parse(engineId)
 	if err != nil {
-		fmt.Println("error while parsing id")
-		return models.Engine{}, err
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
 	}
 
 	// start the transaction
 	tx, err := e.db.BeginTx(ctx, nil)
 	if err != nil {
 		fmt.Println("Error starting a transactions")
-		return models.Engine{}, nil
+		return models.Engine{}, err
 	}
 	defer func() {
 		if err != nil {
@@ -186,44 +295,166 @@ func (e Engine) DeleteEngine(ctx context.Context, engineId string)(models.Engine
 
 	// get the engine
 	getEngineQuery := `
-		SELECT id, displacement, no_of_cylinders, car_range
+		SELECT id, displacement, no_of_cylinders, car_range, version
 		FROM engine 
-		WHERE id=$1
+		WHERE id=$1 AND deleted_at IS NULL
 	`
-	err = tx.QueryRowContext(ctx, getEngineQuery, 
+	err = tx.QueryRowContext(ctx, getEngineQuery,
 		id,
 	).Scan(
-		&deletedEngine.EngineId, 
-		&deletedEngine.Displacement, 
-		&deletedEngine.NoOfCylinders, 
+		&deletedEngine.EngineId,
+		&deletedEngine.Displacement,
+		&deletedEngine.NoOfCylinders,
 		&deletedEngine.CarRange,
+		&deletedEngine.Version,
 	)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = apperrors.NewNotFound("engine", engineId)
+			return models.Engine{}, err
+		}
 		fmt.Println("Error while storing.")
 		return models.Engine{}, err
 	}
 
+	if expectedVersion != 0 && deletedEngine.Version != expectedVersion {
+		err = apperrors.NewPreconditionFailed("engine", engineId)
+		return models.Engine{}, err
+	}
+
+	// the engine as it is before the delete, for the audit log. It is locked
+	// from here on, so no car can be attached to it while its cars are read.
+	before, err := audit.EngineState(ctx, tx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	// find the cars still referencing the engine
+	carIds, err := referencingCars(ctx, tx, id)
+	if err != nil {
+		fmt.Println("Error while looking for cars using the engine")
+		return models.Engine{}, err
+	}
+
+	// the cars as they are before the delete, for the audit log
+	carsBefore, err := carStates(ctx, tx, carIds)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	deletedAt := time.Now()
+	if len(carIds) > 0 {
+		// only the cars read above are changed, those are the ones audited
+		args := []any{id, deletedAt}
+		placeholders := make([]string, len(carIds))
+		for i, carId := range carIds {
+			args = append(args, carId)
+			placeholders[i] = "$" + strconv.Itoa(len(args))
+		}
+		where := `WHERE engine_id = $1 AND deleted_at IS NULL AND id IN (` + strings.Join(placeholders, ", ") + `)`
+
+		switch cascade {
+		case models.CascadeDetach:
+			_, err = tx.ExecContext(ctx, `UPDATE car SET engine_id = NULL, updated_at = $2, version = version + 1 `+where, args...)
+		case models.CascadeDelete:
+			_, err = tx.ExecContext(ctx, `UPDATE car SET deleted_at = $2, updated_at = $2, version = version + 1 `+where, args...)
+		default:
+			err = engineInUse(carIds)
+		}
+		if err != nil {
+			return models.Engine{}, err
+		}
+
+		// a detached car is updated, the others are deleted with the engine
+		operation := models.OperationUpdate
+		if cascade == models.CascadeDelete {
+			operation = models.OperationDelete
+		}
+		err = recordCars(ctx, tx, carIds, operation, carsBefore)
+		if err != nil {
+			fmt.Println("Error recording the audit entries of the cars")
+			return models.Engine{}, err
+		}
+	}
+
 	// query
+	// only delete the version that was read above
 	deleteEngineQuery := `
-		DELETE FROM engine
-		WHERE id=$1
+		UPDATE engine
+		SET deleted_at=$3, version=version+1
+		WHERE id=$1 AND version=$2 AND deleted_at IS NULL
 	`
 
-	result, err :=tx.ExecContext(ctx, deleteEngineQuery, id)
+	result, err := tx.ExecContext(ctx, deleteEngineQuery, id, deletedEngine.Version, deletedAt)
 	if err != nil {
 		fmt.Println("Error while deleting engine")
 		return models.Engine{}, err
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected==0 {
-		fmt.Println("engine with the given id does not exist")
-		return models.Engine{}, errors.New("engine with the given id does not exist")
+	if rowsAffected == 0 {
+		err = missingOrModified(ctx, tx, id)
+		return models.Engine{}, err
 	}
 
+	err = record(ctx, tx, id, models.OperationDelete, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Engine{}, err
+	}
+
+	deletedEngine.DeletedAt = &deletedAt
+	deletedEngine.Version++
 	return deletedEngine, nil
 
+}
+
+// referencingCars lists the live cars using the engine. On postgres they
+// stay locked until tx ends, so the list is still right when they are
+// detached or deleted with the engine.
+func referencingCars(ctx context.Context, tx *store.Tx, engineId uuid.UUID) ([]string, error) {
+	query := `SELECT id FROM car WHERE engine_id = $1 AND deleted_at IS NULL ORDER BY id`
+	if tx.Dialect() == store.Postgres {
+		query += " FOR UPDATE"
+	}
+
+	rows, err := tx.QueryContext(ctx, query, engineId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	carIds := []string{}
+	for rows.Next() {
+		var carId string
+		if err := rows.Scan(&carId); err != nil {
+			return nil, err
+		}
+		carIds = append(carIds, carId)
+	}
+	return carIds, rows.Err()
+}
+
+// engineInUse is the conflict returned when cars still reference the engine.
+func engineInUse(carIds []string) error {
+	conflict := apperrors.NewConflict("engine is still used by one or more cars, delete them first or use cascade=detach or cascade=delete")
+	if carIds != nil {
+		conflict.Details = map[string]any{"car_ids": carIds}
+	}
+	return conflict
+}
 
-	
+// missingOrModified tells why a conditional write matched no row: the
+// engine is gone, or it has another version than the one expected.
+func missingOrModified(ctx context.Context, tx *store.Tx, id uuid.UUID) error {
+	var exists int
+	err := tx.QueryRowContext(ctx, `SELECT 1 FROM engine WHERE id=$1 AND deleted_at IS NULL`, id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.NewNotFound("engine", id.String())
+	}
+	if err != nil {
+		return err
+	}
+	return apperrors.NewPreconditionFailed("engine", id.String())
 }
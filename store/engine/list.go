@@ -0,0 +1,135 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+)
+
+var engineSortColumns = map[string]string{
+	"displacement":    "e.displacement",
+	"no_of_cylinders": "e.no_of_cylinders",
+	"car_range":       "e.car_range",
+}
+
+// ListEngines returns one page of engines matching the filter, optionally
+// with the number of cars using each of them.
+func (e Engine) ListEngines(ctx context.Context, filter models.EngineFilter) (models.EnginePage, error) {
+	var where []string
+	var args []any
+
+	add := func(condition string, arg *int64) {
+		if arg == nil {
+			return
+		}
+		args = append(args, *arg)
+		where = append(where, condition+" $"+strconv.Itoa(len(args)))
+	}
+	add("e.displacement >=", filter.MinDisplacement)
+	add("e.displacement <=", filter.MaxDisplacement)
+	add("e.no_of_cylinders >=", filter.MinNoOfCylinders)
+	add("e.no_of_cylinders <=", filter.MaxNoOfCylinders)
+	add("e.car_range >=", filter.MinCarRange)
+	add("e.car_range <=", filter.MaxCarRange)
+
+	// resume after the cursor position
+	if filter.Cursor != "" {
+		cursor, err := store.DecodeCursor(filter.Cursor, models.SortSpec(filter.Sort), len(filter.Sort))
+		if err != nil {
+			return models.EnginePage{}, err
+		}
+
+		exprs := make([]string, len(filter.Sort))
+		desc := make([]bool, len(filter.Sort))
+		values := make([]any, len(filter.Sort))
+		for i, sort := range filter.Sort {
+			exprs[i] = engineSortColumns[sort.Field]
+			desc[i] = sort.Desc
+			values[i], err = store.CursorValue(cursor.Values[i], models.EngineSortValue(models.Engine{}, sort.Field))
+			if err != nil {
+				return models.EnginePage{}, err
+			}
+		}
+
+		condition, keysetArgs := store.KeysetCondition(exprs, desc, values, "e.id", cursor.ID, len(args)+1)
+		where = append(where, condition)
+		args = append(args, keysetArgs...)
+	}
+
+	// hide soft deleted engines unless asked for
+	if notDeleted := store.NotDeleted(ctx, "e"); notDeleted != "" {
+		where = append(where, notDeleted)
+	}
+
+	query := `SELECT e.id, e.displacement, e.no_of_cylinders, e.car_range, e.version, e.deleted_at`
+	if filter.IncludeCarCount {
+		query += `, (SELECT COUNT(*) FROM car c WHERE c.engine_id = e.id AND c.deleted_at IS NULL) AS car_count`
+	}
+	query += "\n\t\tFROM engine e"
+	if len(where) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(where, " AND ")
+	}
+
+	order := make([]string, 0, len(filter.Sort)+1)
+	for _, sort := range filter.Sort {
+		direction := " ASC"
+		if sort.Desc {
+			direction = " DESC"
+		}
+		order = append(order, engineSortColumns[sort.Field]+direction)
+	}
+	order = append(order, "e.id ASC")
+	query += "\n\t\tORDER BY " + strings.Join(order, ", ")
+	// fetch one extra row to know whether there is a next page
+	query += "\n\t\tLIMIT " + strconv.Itoa(filter.Limit+1)
+
+	rows, err := e.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.EnginePage{}, err
+	}
+	defer rows.Close()
+
+	engines := []models.Engine{}
+	for rows.Next() {
+		var engine models.Engine
+		var deletedAt sql.NullTime
+		dest := []any{&engine.EngineId, &engine.Displacement, &engine.NoOfCylinders, &engine.CarRange, &engine.Version, &deletedAt}
+
+		var carCount sql.NullInt64
+		if filter.IncludeCarCount {
+			dest = append(dest, &carCount)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return models.EnginePage{}, err
+		}
+		if filter.IncludeCarCount {
+			engine.CarCount = &carCount.Int64
+		}
+		if deletedAt.Valid {
+			engine.DeletedAt = &deletedAt.Time
+		}
+
+		engines = append(engines, engine)
+	}
+	if err := rows.Err(); err != nil {
+		return models.EnginePage{}, err
+	}
+
+	page := models.EnginePage{Engines: engines}
+	if len(engines) > filter.Limit {
+		page.Engines = engines[:filter.Limit]
+		last := page.Engines[len(page.Engines)-1]
+
+		values := make([]any, len(filter.Sort))
+		for i, sort := range filter.Sort {
+			values[i] = models.EngineSortValue(last, sort.Field)
+		}
+		page.NextCursor = store.Cursor{Sort: models.SortSpec(filter.Sort), Values: values, ID: last.EngineId.String()}.Encode()
+	}
+
+	return page, nil
+}
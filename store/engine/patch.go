@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// PatchEngine updates only the columns set in patch. expectedVersion, when
+// not zero, makes the update fail unless the engine still has that version.
+func (e Engine) PatchEngine(ctx context.Context, engineId string, patch models.EnginePatch, expectedVersion int64) (_ models.Engine, err error) {
+
+	// parse string id into uuid.UUID
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	var set []string
+	args := []any{id}
+
+	add := func(column string, arg any) {
+		args = append(args, arg)
+		set = append(set, column+"=$"+strconv.Itoa(len(args)))
+	}
+
+	if patch.Displacement != nil {
+		add("displacement", *patch.Displacement)
+	}
+	if patch.NoOfCylinders != nil {
+		add("no_of_cylinders", *patch.NoOfCylinders)
+	}
+	if patch.CarRange != nil {
+		add("car_range", *patch.CarRange)
+	}
+	set = append(set, "version=version+1")
+
+	where := "id=$1 AND deleted_at IS NULL"
+	if expectedVersion != 0 {
+		args = append(args, expectedVersion)
+		where += " AND version=$" + strconv.Itoa(len(args))
+	}
+
+	// start transaction
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Error while starting transaction")
+		return models.Engine{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the engine as it is before the update, for the audit log
+	before, err := audit.EngineState(ctx, tx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+
+	// store patched engine
+	var patchedEngine models.Engine
+
+	query := `
+		UPDATE engine
+		SET ` + strings.Join(set, ", ") + `
+		WHERE ` + where + `
+		RETURNING id, displacement, no_of_cylinders, car_range, version
+	`
+	err = tx.QueryRowContext(ctx, query, args...).Scan(
+		&patchedEngine.EngineId,
+		&patchedEngine.Displacement,
+		&patchedEngine.NoOfCylinders,
+		&patchedEngine.CarRange,
+		&patchedEngine.Version,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = missingOrModified(ctx, tx, id)
+			return models.Engine{}, err
+		}
+		fmt.Println("Error while patching engine")
+		return models.Engine{}, err
+	}
+
+	err = record(ctx, tx, id, models.OperationUpdate, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Engine{}, err
+	}
+
+	return patchedEngine, nil
+}
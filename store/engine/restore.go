@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+// RestoreEngine brings a soft deleted engine back. Cars deleted along with
+// it are restored one by one.
+func (e Engine) RestoreEngine(ctx context.Context, engineId string) (_ models.Engine, err error) {
+
+	// parse string id into uuid.UUID
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	// store restored engine
+	var restoredEngine models.Engine
+
+	query := `
+		UPDATE engine
+		SET deleted_at=NULL, version=version+1
+		WHERE id=$1
+		RETURNING id, displacement, no_of_cylinders, car_range, version
+	`
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Error while starting transaction")
+		return models.Engine{}, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the engine as it is before the restore, for the audit log
+	before, err := audit.EngineState(ctx, tx, id)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	if before == nil {
+		err = apperrors.NewNotFound("engine", engineId)
+		return models.Engine{}, err
+	}
+	if before.DeletedAt == nil {
+		err = apperrors.NewConflict("engine is not deleted")
+		return models.Engine{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, query, id).Scan(
+		&restoredEngine.EngineId,
+		&restoredEngine.Displacement,
+		&restoredEngine.NoOfCylinders,
+		&restoredEngine.CarRange,
+		&restoredEngine.Version,
+	)
+	if err != nil {
+		fmt.Println("Error while restoring engine")
+		return models.Engine{}, err
+	}
+
+	err = record(ctx, tx, id, models.OperationRestore, before)
+	if err != nil {
+		fmt.Println("Error recording the audit entry")
+		return models.Engine{}, err
+	}
+
+	return restoredEngine, nil
+}
+
+// PurgeEngines hard deletes the engines soft deleted before the given
+// time and returns how many were removed. Engines still referenced by a
+// car, even a soft deleted one, are kept until that car is purged. Every
+// purged engine is audited with its last state.
+func (e Engine) PurgeEngines(ctx context.Context, before time.Time) (_ int64, err error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		fmt.Println("Error while starting transaction")
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	engineIds, err := purgeableEngines(ctx, tx, before)
+	if err != nil {
+		fmt.Println("Error while looking for engines to purge")
+		return 0, err
+	}
+
+	for _, id := range engineIds {
+		var state *models.Engine
+		state, err = audit.EngineState(ctx, tx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM engine WHERE id=$1`, id)
+		if err != nil {
+			fmt.Println("Error while purging engines")
+			return 0, err
+		}
+
+		err = record(ctx, tx, id, models.OperationPurge, state)
+		if err != nil {
+			fmt.Println("Error recording the audit entry")
+			return 0, err
+		}
+	}
+
+	return int64(len(engineIds)), nil
+}
+
+func purgeableEngines(ctx context.Context, tx *store.Tx, before time.Time) ([]uuid.UUID, error) {
+	query := `
+		SELECT id FROM engine
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM car c WHERE c.engine_id = engine.id)
+		ORDER BY id
+	`
+	rows, err := tx.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	engineIds := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		engineIds = append(engineIds, id)
+	}
+	return engineIds, rows.Err()
+}
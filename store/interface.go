@@ -2,24 +2,67 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/TheMikeKaisen/CarManagement/models"
 )
 
+// Writes of both stores take an expectedVersion: when it is not zero the
+// write fails with a precondition error unless the record still has that
+// version. Deletes are soft: reads skip the tombstones unless the context
+// comes from WithDeleted, and Purge* removes them for good.
 type CarStoreInterface interface {
+	HistoryStoreInterface
+
 	GetCarById(ctx context.Context, id string) (models.Car, error)
 	GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error)
+	ListCars(ctx context.Context, filter models.CarFilter) (models.CarPage, error)
+	ExportCars(ctx context.Context, filter models.CarFilter, each func(models.Car) error) error
 	CreateCar(ctx context.Context, carReq models.CarRequest) (models.Car, error)
-	UpdateCar(ctx context.Context, id string, carReq *models.CarRequest) (models.Car, error)
-	DeleteCar(ctx context.Context, id string) (models.Car, error)
+	UpdateCar(ctx context.Context, id string, carReq *models.CarRequest, expectedVersion int64) (models.Car, error)
+	PatchCar(ctx context.Context, id string, patch models.CarPatch, expectedVersion int64) (models.Car, error)
+	DeleteCar(ctx context.Context, id string, expectedVersion int64) (models.Car, error)
+	RestoreCar(ctx context.Context, id string) (models.Car, error)
+	PurgeCars(ctx context.Context, before time.Time) (int64, error)
+	CarPrices(ctx context.Context, id string) ([]models.PricePoint, error)
+	PriceStats(ctx context.Context, groupBy string) ([]models.PriceStats, error)
+	ImportCars(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) ([]models.ImportResult, error)
 }
 
 type EngineStoreInterface interface{
+	HistoryStoreInterface
+
 	CreateEngine(ctx context.Context, engineReq *models.EngineRequest) (models.Engine, error) 
 
 	GetEngineById(ctx context.Context, engineId string) (models.Engine, error)
 
-	UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest) (models.Engine, error)
+	GetEnginesByIds(ctx context.Context, engineIds []string) ([]models.Engine, error)
+
+	ListEngines(ctx context.Context, filter models.EngineFilter) (models.EnginePage, error)
+
+	UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest, expectedVersion int64) (models.Engine, error)
+
+	PatchEngine(ctx context.Context, engineId string, patch models.EnginePatch, expectedVersion int64) (models.Engine, error)
+
+	DeleteEngine(ctx context.Context, engineId string, cascade models.CascadeMode, expectedVersion int64) (models.Engine, error)
 
-	DeleteEngine(ctx context.Context, engineId string)(models.Engine, error)
-}
\ No newline at end of file
+	RestoreEngine(ctx context.Context, engineId string) (models.Engine, error)
+
+	PurgeEngines(ctx context.Context, before time.Time) (int64, error)
+}
+
+// HistoryStoreInterface reads the audit log that every create, update and
+// delete of both stores appends to in the same transaction. Either store
+// can read the history of a car as well as of an engine.
+type HistoryStoreInterface interface {
+	History(ctx context.Context, entity string, id string) ([]models.AuditEntry, error)
+}
+
+// APIKeyStoreInterface keeps the api keys by the hash of the key, the key
+// itself is never stored. Revoked keys are listed but never found by hash.
+type APIKeyStoreInterface interface {
+	CreateAPIKey(ctx context.Context, key models.APIKey) (models.APIKey, error)
+	APIKeyByHash(ctx context.Context, hash string) (models.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (models.APIKey, error)
+}
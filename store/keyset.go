@@ -0,0 +1,95 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+)
+
+// KeysetCondition builds the WHERE fragment selecting the rows that come
+// after the cursor position for the given sort expressions, with idExpr as
+// the final ascending tie breaker:
+//
+//	(a > $1) OR (a = $1 AND b < $2) OR (a = $1 AND b = $2 AND id > $3)
+//
+// Placeholders are numbered from firstArg; the matching args are returned.
+func KeysetCondition(exprs []string, desc []bool, values []any, idExpr string, id string, firstArg int) (string, []any) {
+	exprs = append(append([]string{}, exprs...), idExpr)
+	desc = append(append([]bool{}, desc...), false)
+	values = append(append([]any{}, values...), id)
+
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = "$" + strconv.Itoa(firstArg+i)
+	}
+
+	var branches []string
+	for i := range exprs {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, exprs[j]+" = "+placeholders[j])
+		}
+		op := ">"
+		if desc[i] {
+			op = "<"
+		}
+		parts = append(parts, exprs[i]+" "+op+" "+placeholders[i])
+		branches = append(branches, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")", values
+}
+
+// CursorValue converts a value decoded from a cursor token back into the
+// go type of like (string, float64, int64 or time.Time).
+func CursorValue(raw any, like any) (any, error) {
+	invalid := apperrors.NewBadRequest("invalid cursor", nil)
+
+	switch like.(type) {
+	case string:
+		value, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		return value, nil
+
+	case float64:
+		number, ok := raw.(json.Number)
+		if !ok {
+			return nil, invalid
+		}
+		value, err := number.Float64()
+		if err != nil {
+			return nil, invalid
+		}
+		return value, nil
+
+	case int64:
+		number, ok := raw.(json.Number)
+		if !ok {
+			return nil, invalid
+		}
+		value, err := number.Int64()
+		if err != nil {
+			return nil, invalid
+		}
+		return value, nil
+
+	case time.Time:
+		text, ok := raw.(string)
+		if !ok {
+			return nil, invalid
+		}
+		value, err := time.Parse(time.RFC3339Nano, text)
+		if err != nil {
+			return nil, invalid
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("unsupported cursor value type %T", like)
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+func (s *Store) CreateAPIKey(ctx context.Context, key models.APIKey) (models.APIKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apiKeys[key.ID] = key
+	return key, nil
+}
+
+func (s *Store) APIKeyByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, key := range s.apiKeys {
+		if key.Hash == hash && key.RevokedAt == nil {
+			return key, nil
+		}
+	}
+	return models.APIKey{}, apperrors.NewNotFound("api key", "")
+}
+
+func (s *Store) ListAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := []models.APIKey{}
+	for _, key := range s.apiKeys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID.String() < keys[j].ID.String()
+	})
+	return keys, nil
+}
+
+func (s *Store) RevokeAPIKey(ctx context.Context, id string) (models.APIKey, error) {
+	keyId, err := uuid.Parse(id)
+	if err != nil {
+		return models.APIKey{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.apiKeys[keyId]
+	if !ok {
+		return models.APIKey{}, apperrors.NewNotFound("api key", id)
+	}
+	if key.RevokedAt == nil {
+		revokedAt := time.Now()
+		key.RevokedAt = &revokedAt
+		s.apiKeys[keyId] = key
+	}
+	return key, nil
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+func (s *Store) History(ctx context.Context, entity string, id string) ([]models.AuditEntry, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := []models.AuditEntry{}
+	for _, entry := range s.history {
+		if entry.Entity == entity && entry.EntityID == id {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// record appends the audit entry of a write. It is called before the maps
+// are changed, so a write that cannot be audited leaves no trace.
+func (s *Store) record(ctx context.Context, entity string, id uuid.UUID, operation string, before any, after any) error {
+	entry, err := audit.NewEntry(ctx, entity, id.String(), operation, before, after)
+	if err != nil {
+		return err
+	}
+
+	s.appendHistory(entry)
+	return nil
+}
+
+// appendHistory numbers and appends entries built with audit.NewEntry, for
+// writes that change several records and audit all of them first.
+func (s *Store) appendHistory(entries ...models.AuditEntry) {
+	for _, entry := range entries {
+		entry.ID = int64(len(s.history) + 1)
+		s.history = append(s.history, entry)
+	}
+}
+
+// carState is a stored car the way the audit log records it.
+func carState(car models.Car) *models.CarState {
+	state := models.NewCarState(car)
+	return &state
+}
@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+)
+
+// ExportCars calls each for every car matching the filter, sorted like
+// the sql store. The cars are copied out first so each runs without the lock.
+func (s *Store) ExportCars(ctx context.Context, filter models.CarFilter, each func(models.Car) error) error {
+	cars := s.matchingCars(ctx, filter)
+
+	cars, _, err := paginate(cars, filter.Sort, models.CarSortValue, carId, "", len(cars))
+	if err != nil {
+		return err
+	}
+
+	for _, car := range cars {
+		if err := each(car); err != nil {
+			return err
+		}
+	}
+	return nil
+}
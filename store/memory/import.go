@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+// ImportCars creates the cars of rows under a single lock. Dry runs and
+// atomic imports with a failed row are undone from a snapshot, like the
+// rolled back transaction of the sql store.
+func (s *Store) ImportCars(ctx context.Context, rows []models.ImportRow, opts models.ImportOptions) ([]models.ImportResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// only imports that may be undone pay for the copy
+	var restore func()
+	if opts.DryRun || opts.Atomic {
+		restore = s.snapshot()
+	}
+
+	results := make([]models.ImportResult, 0, len(rows))
+	failed := false
+	for _, row := range rows {
+		result, err := s.importRow(ctx, row)
+		if err != nil {
+			if restore != nil {
+				restore()
+			}
+			return nil, err
+		}
+		failed = failed || result.Status == models.ImportFailed
+		results = append(results, result)
+	}
+
+	if opts.DryRun || (opts.Atomic && failed) {
+		restore()
+	}
+	return results, nil
+}
+
+func (s *Store) importRow(ctx context.Context, row models.ImportRow) (models.ImportResult, error) {
+	engine, engineCreated, err := s.importEngine(ctx, row.Car.Engine)
+	if err != nil {
+		if apperrors.IsValidation(err) {
+			return models.NewImportFailure(row.Line, err), nil
+		}
+		return models.ImportResult{}, err
+	}
+
+	carReq := row.Car
+	carReq.Engine = engine
+	car, err := s.createCar(ctx, carReq)
+	if err != nil {
+		return models.ImportResult{}, err
+	}
+
+	return models.ImportResult{
+		Line:          row.Line,
+		Status:        models.ImportCreated,
+		CarID:         &car.ID,
+		EngineID:      &engine.EngineId,
+		EngineCreated: engineCreated,
+	}, nil
+}
+
+// importEngine picks the engine of an imported car the same way the sql
+// store does: by id, else the first live engine by id with the same specs,
+// else a new one.
+func (s *Store) importEngine(ctx context.Context, spec models.Engine) (models.Engine, bool, error) {
+	if spec.EngineId != uuid.Nil {
+		if !s.liveEngine(spec.EngineId) {
+			return models.Engine{}, false, errEngineNotFound
+		}
+		return s.engines[spec.EngineId], false, nil
+	}
+
+	var match *models.Engine
+	for _, engine := range s.engines {
+		if engine.DeletedAt != nil || engine.Displacement != spec.Displacement ||
+			engine.NoOfCylinders != spec.NoOfCylinders || engine.CarRange != spec.CarRange {
+			continue
+		}
+		if match == nil || engine.EngineId.String() < match.EngineId.String() {
+			match = &engine
+		}
+	}
+	if match != nil {
+		return *match, false, nil
+	}
+
+	engine, err := s.createEngine(ctx, &models.EngineRequest{
+		Displacement:  spec.Displacement,
+		NoOfCylinders: spec.NoOfCylinders,
+		CarRange:      spec.CarRange,
+	})
+	return engine, true, err
+}
+
+// snapshot copies the state of the store and returns a func putting it
+// back, undoing every write made in between. The caller holds the lock.
+func (s *Store) snapshot() func() {
+	cars := maps.Clone(s.cars)
+	engines := maps.Clone(s.engines)
+	prices := make(map[uuid.UUID][]models.PricePoint, len(s.prices))
+	for id, points := range s.prices {
+		prices[id] = slices.Clone(points)
+	}
+	history := len(s.history)
+
+	return func() {
+		s.cars = cars
+		s.engines = engines
+		s.prices = prices
+		s.history = s.history[:history]
+	}
+}
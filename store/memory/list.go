@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) ListCars(ctx context.Context, filter models.CarFilter) (models.CarPage, error) {
+	cars := s.matchingCars(ctx, filter)
+
+	cars, nextCursor, err := paginate(cars, filter.Sort, models.CarSortValue, carId, filter.Cursor, filter.Limit)
+	if err != nil {
+		return models.CarPage{}, err
+	}
+
+	return models.CarPage{Cars: cars, NextCursor: nextCursor}, nil
+}
+
+// matchingCars returns the cars passing the filter, with their engine, in
+// no particular order.
+func (s *Store) matchingCars(ctx context.Context, filter models.CarFilter) []models.Car {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var cars []models.Car
+	for _, car := range s.cars {
+		if car.DeletedAt != nil && !store.IncludeDeleted(ctx) {
+			continue
+		}
+		car.Engine = s.engineOf(car)
+		if matchesCarFilter(car, filter) {
+			cars = append(cars, car)
+		}
+	}
+	return cars
+}
+
+func carId(car models.Car) string {
+	return car.ID.String()
+}
+
+// paginate sorts items by the requested keys, then by id like the sql
+// stores, and cuts out the page that follows the cursor.
+func paginate[T any](items []T, sortFields []models.SortField, sortValue func(T, string) any, id func(T) string, token string, limit int) ([]T, string, error) {
+	compare := func(item T, values []any, itemId string) int {
+		for i, field := range sortFields {
+			result := compareValues(sortValue(item, field.Field), values[i])
+			if field.Desc {
+				result = -result
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return strings.Compare(id(item), itemId)
+	}
+	valuesOf := func(item T) []any {
+		values := make([]any, len(sortFields))
+		for i, field := range sortFields {
+			values[i] = sortValue(item, field.Field)
+		}
+		return values
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return compare(items[i], valuesOf(items[j]), id(items[j])) < 0
+	})
+
+	// skip everything up to and including the cursor position
+	if token != "" {
+		cursor, err := store.DecodeCursor(token, models.SortSpec(sortFields), len(sortFields))
+		if err != nil {
+			return nil, "", err
+		}
+
+		var zero T
+		values := make([]any, len(sortFields))
+		for i, field := range sortFields {
+			values[i], err = store.CursorValue(cursor.Values[i], sortValue(zero, field.Field))
+			if err != nil {
+				return nil, "", err
+			}
+		}
+
+		start := sort.Search(len(items), func(i int) bool {
+			return compare(items[i], values, cursor.ID) > 0
+		})
+		items = items[start:]
+	}
+
+	if len(items) <= limit {
+		return append([]T{}, items...), "", nil
+	}
+
+	page := items[:limit]
+	last := page[len(page)-1]
+	cursor := store.Cursor{Sort: models.SortSpec(sortFields), Values: valuesOf(last), ID: id(last)}
+	return page, cursor.Encode(), nil
+}
+
+func matchesCarFilter(car models.Car, filter models.CarFilter) bool {
+	if filter.Brand != "" && car.Brand != filter.Brand {
+		return false
+	}
+	if filter.FuelType != "" && car.FuelType != filter.FuelType {
+		return false
+	}
+	if filter.MinYear != nil && car.Year < strconv.Itoa(*filter.MinYear) {
+		return false
+	}
+	if filter.MaxYear != nil && car.Year > strconv.Itoa(*filter.MaxYear) {
+		return false
+	}
+	if filter.PriceCurrency != "" && car.Price.Currency != filter.PriceCurrency {
+		return false
+	}
+	if filter.MinPrice != nil && car.Price.AmountMinor < filter.MinPrice.AmountMinor {
+		return false
+	}
+	if filter.MaxPrice != nil && car.Price.AmountMinor > filter.MaxPrice.AmountMinor {
+		return false
+	}
+	return inRange(car.Engine.Displacement, filter.MinDisplacement, filter.MaxDisplacement) &&
+		inRange(car.Engine.NoOfCylinders, filter.MinNoOfCylinders, filter.MaxNoOfCylinders) &&
+		inRange(car.Engine.CarRange, filter.MinCarRange, filter.MaxCarRange)
+}
+
+func inRange(value int64, min *int64, max *int64) bool {
+	if min != nil && value < *min {
+		return false
+	}
+	if max != nil && value > *max {
+		return false
+	}
+	return true
+}
+
+// compareValues orders two values of the same sortable type.
+func compareValues(a any, b any) int {
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case float64:
+		return compareOrdered(a, b.(float64))
+	case int64:
+		return compareOrdered(a, b.(int64))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
+
+func compareOrdered[T int64 | float64](a T, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (s *Store) ListEngines(ctx context.Context, filter models.EngineFilter) (models.EnginePage, error) {
+	s.mu.RLock()
+	carCounts := make(map[uuid.UUID]int64)
+	for _, car := range s.cars {
+		if car.DeletedAt == nil {
+			carCounts[car.Engine.EngineId]++
+		}
+	}
+
+	var engines []models.Engine
+	for _, engine := range s.engines {
+		if engine.DeletedAt != nil && !store.IncludeDeleted(ctx) {
+			continue
+		}
+		if !inRange(engine.Displacement, filter.MinDisplacement, filter.MaxDisplacement) ||
+			!inRange(engine.NoOfCylinders, filter.MinNoOfCylinders, filter.MaxNoOfCylinders) ||
+			!inRange(engine.CarRange, filter.MinCarRange, filter.MaxCarRange) {
+			continue
+		}
+		if filter.IncludeCarCount {
+			count := carCounts[engine.EngineId]
+			engine.CarCount = &count
+		}
+		engines = append(engines, engine)
+	}
+	s.mu.RUnlock()
+
+	engines, nextCursor, err := paginate(engines, filter.Sort, models.EngineSortValue, engineId, filter.Cursor, filter.Limit)
+	if err != nil {
+		return models.EnginePage{}, err
+	}
+
+	return models.EnginePage{Engines: engines, NextCursor: nextCursor}, nil
+}
+
+func engineId(engine models.Engine) string {
+	return engine.EngineId.String()
+}
@@ -0,0 +1,398 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/TheMikeKaisen/CarManagement/store/audit"
+	"github.com/google/uuid"
+)
+
+var (
+	_ store.CarStoreInterface    = (*Store)(nil)
+	_ store.EngineStoreInterface = (*Store)(nil)
+	_ store.APIKeyStoreInterface = (*Store)(nil)
+)
+
+// errEngineNotFound is reported when a car references a missing engine.
+var errEngineNotFound = apperrors.NewFieldValidation([]apperrors.FieldError{
+	{Field: "engine.engine_id", Code: "not_found", Message: "engine_id does not exists in the engine table"},
+})
+
+// Store keeps cars and engines in maps guarded by a single lock. It
+// implements both store.CarStoreInterface and store.EngineStoreInterface
+// with the same semantics as the postgres stores.
+type Store struct {
+	mu      sync.RWMutex
+	cars    map[uuid.UUID]models.Car
+	engines map[uuid.UUID]models.Engine
+
+	// prices is the price history of every car, oldest first
+	prices map[uuid.UUID][]models.PricePoint
+
+	// history is the audit log, appended to by every write
+	history []models.AuditEntry
+
+	// apiKeys are the hashed api keys, lost on restart like everything else
+	apiKeys map[uuid.UUID]models.APIKey
+}
+
+func New() *Store {
+	return &Store{
+		cars:    make(map[uuid.UUID]models.Car),
+		engines: make(map[uuid.UUID]models.Engine),
+		prices:  make(map[uuid.UUID][]models.PricePoint),
+		apiKeys: make(map[uuid.UUID]models.APIKey),
+	}
+}
+
+func (s *Store) GetCarById(ctx context.Context, id string) (models.Car, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	car, ok := s.cars[carId]
+	if !ok || (car.DeletedAt != nil && !store.IncludeDeleted(ctx)) {
+		return models.Car{}, apperrors.NewNotFound("car", id)
+	}
+
+	// cars are stored with only the engine id, join the engine like the sql store does
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) GetCarByBrand(ctx context.Context, brand string, isEngine bool) ([]models.Car, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cars := []models.Car{}
+	for _, car := range s.cars {
+		if car.Brand != brand || (car.DeletedAt != nil && !store.IncludeDeleted(ctx)) {
+			continue
+		}
+		if isEngine {
+			car.Engine = s.engineOf(car)
+		}
+		cars = append(cars, car)
+	}
+
+	return cars, nil
+}
+
+func (s *Store) CreateCar(ctx context.Context, carReq models.CarRequest) (models.Car, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createCar(ctx, carReq)
+}
+
+// createCar is CreateCar for callers already holding the lock.
+func (s *Store) createCar(ctx context.Context, carReq models.CarRequest) (models.Car, error) {
+	// check whether the engineId exists or not
+	if !s.liveEngine(carReq.Engine.EngineId) {
+		return models.Car{}, errEngineNotFound
+	}
+	if err := models.ValidateEngineSpecs(carReq.Engine, s.engines[carReq.Engine.EngineId]); err != nil {
+		return models.Car{}, err
+	}
+
+	createdAt := time.Now()
+	car := models.Car{
+		ID:        uuid.New(),
+		Name:      carReq.Name,
+		Year:      carReq.Year,
+		Brand:     carReq.Brand,
+		FuelType:  carReq.FuelType,
+		Engine:    models.Engine{EngineId: carReq.Engine.EngineId},
+		Price:     carReq.Price,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		Version:   1,
+	}
+	if err := s.record(ctx, models.EntityCar, car.ID, models.OperationCreate, nil, carState(car)); err != nil {
+		return models.Car{}, err
+	}
+	s.cars[car.ID] = car
+	s.recordPrice(car.ID, car.Price, createdAt)
+
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) UpdateCar(ctx context.Context, id string, carReq *models.CarRequest, expectedVersion int64) (models.Car, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	car, ok := s.cars[carId]
+	if !ok || car.DeletedAt != nil {
+		return models.Car{}, apperrors.NewNotFound("car", id)
+	}
+	if expectedVersion != 0 && car.Version != expectedVersion {
+		return models.Car{}, apperrors.NewPreconditionFailed("car", id)
+	}
+	if !s.liveEngine(carReq.Engine.EngineId) {
+		return models.Car{}, errEngineNotFound
+	}
+	if err := models.ValidateEngineSpecs(carReq.Engine, s.engines[carReq.Engine.EngineId]); err != nil {
+		return models.Car{}, err
+	}
+
+	before := car
+	car.Name = carReq.Name
+	car.Year = carReq.Year
+	car.Brand = carReq.Brand
+	car.FuelType = carReq.FuelType
+	car.Engine = models.Engine{EngineId: carReq.Engine.EngineId}
+	car.Price = carReq.Price
+	car.UpdatedAt = time.Now()
+	car.Version++
+	if err := s.record(ctx, models.EntityCar, carId, models.OperationUpdate, carState(before), carState(car)); err != nil {
+		return models.Car{}, err
+	}
+	s.cars[carId] = car
+	if car.Price != before.Price {
+		s.recordPrice(carId, car.Price, car.UpdatedAt)
+	}
+
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) DeleteCar(ctx context.Context, id string, expectedVersion int64) (models.Car, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	car, ok := s.cars[carId]
+	if !ok || car.DeletedAt != nil {
+		return models.Car{}, apperrors.NewNotFound("car", id)
+	}
+	if expectedVersion != 0 && car.Version != expectedVersion {
+		return models.Car{}, apperrors.NewPreconditionFailed("car", id)
+	}
+
+	// keep a tombstone until the car is restored or purged
+	before := car
+	deletedAt := time.Now()
+	car.DeletedAt = &deletedAt
+	car.UpdatedAt = deletedAt
+	car.Version++
+	if err := s.record(ctx, models.EntityCar, carId, models.OperationDelete, carState(before), carState(car)); err != nil {
+		return models.Car{}, err
+	}
+	s.cars[carId] = car
+
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) CreateEngine(ctx context.Context, engineReq *models.EngineRequest) (models.Engine, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.createEngine(ctx, engineReq)
+}
+
+// createEngine is CreateEngine for callers already holding the lock.
+func (s *Store) createEngine(ctx context.Context, engineReq *models.EngineRequest) (models.Engine, error) {
+	engine := models.Engine{
+		EngineId:      uuid.New(),
+		Displacement:  engineReq.Displacement,
+		NoOfCylinders: engineReq.NoOfCylinders,
+		CarRange:      engineReq.CarRange,
+		Version:       1,
+	}
+	if err := s.record(ctx, models.EntityEngine, engine.EngineId, models.OperationCreate, nil, &engine); err != nil {
+		return models.Engine{}, err
+	}
+	s.engines[engine.EngineId] = engine
+
+	return engine, nil
+}
+
+func (s *Store) GetEngineById(ctx context.Context, engineId string) (models.Engine, error) {
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	engine, ok := s.engines[id]
+	if !ok || (engine.DeletedAt != nil && !store.IncludeDeleted(ctx)) {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+
+	return engine, nil
+}
+
+func (s *Store) GetEnginesByIds(ctx context.Context, engineIds []string) ([]models.Engine, error) {
+	ids := make([]uuid.UUID, len(engineIds))
+	for i, engineId := range engineIds {
+		id, err := uuid.Parse(engineId)
+		if err != nil {
+			return nil, apperrors.NewInvalidID(engineId, err)
+		}
+		ids[i] = id
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	engines := []models.Engine{}
+	for _, id := range ids {
+		engine, ok := s.engines[id]
+		if !ok || (engine.DeletedAt != nil && !store.IncludeDeleted(ctx)) {
+			continue
+		}
+		engines = append(engines, engine)
+	}
+	return engines, nil
+}
+
+func (s *Store) UpdateEngine(ctx context.Context, engineId string, engineReq *models.EngineRequest, expectedVersion int64) (models.Engine, error) {
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, ok := s.engines[id]
+	if !ok || current.DeletedAt != nil {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+	if expectedVersion != 0 && current.Version != expectedVersion {
+		return models.Engine{}, apperrors.NewPreconditionFailed("engine", engineId)
+	}
+
+	engine := models.Engine{
+		EngineId:      id,
+		Displacement:  engineReq.Displacement,
+		NoOfCylinders: engineReq.NoOfCylinders,
+		CarRange:      engineReq.CarRange,
+		Version:       current.Version + 1,
+	}
+	if err := s.record(ctx, models.EntityEngine, id, models.OperationUpdate, &current, &engine); err != nil {
+		return models.Engine{}, err
+	}
+	s.engines[id] = engine
+
+	return engine, nil
+}
+
+func (s *Store) DeleteEngine(ctx context.Context, engineId string, cascade models.CascadeMode, expectedVersion int64) (models.Engine, error) {
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	engine, ok := s.engines[id]
+	if !ok || engine.DeletedAt != nil {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+	if expectedVersion != 0 && engine.Version != expectedVersion {
+		return models.Engine{}, apperrors.NewPreconditionFailed("engine", engineId)
+	}
+
+	// find the live cars still using the engine
+	var carIds []string
+	for _, car := range s.cars {
+		if car.Engine.EngineId == id && car.DeletedAt == nil {
+			carIds = append(carIds, car.ID.String())
+		}
+	}
+	sort.Strings(carIds)
+
+	// build the new state of every record first, the maps only change once
+	// all of it, audit entries included, could be built
+	deletedAt := time.Now()
+	var cars []models.Car
+	var entries []models.AuditEntry
+	if len(carIds) > 0 {
+		var operation string
+		switch cascade {
+		case models.CascadeDetach:
+			operation = models.OperationUpdate
+		case models.CascadeDelete:
+			operation = models.OperationDelete
+		default:
+			conflict := apperrors.NewConflict("engine is still used by one or more cars, delete them first or use cascade=detach or cascade=delete")
+			conflict.Details = map[string]any{"car_ids": carIds}
+			return models.Engine{}, conflict
+		}
+
+		for _, carId := range carIds {
+			car := s.cars[uuid.MustParse(carId)]
+			before := car
+			if cascade == models.CascadeDetach {
+				car.Engine = models.Engine{}
+			} else {
+				car.DeletedAt = &deletedAt
+			}
+			car.UpdatedAt = deletedAt
+			car.Version++
+
+			entry, err := audit.NewEntry(ctx, models.EntityCar, carId, operation, carState(before), carState(car))
+			if err != nil {
+				return models.Engine{}, err
+			}
+			cars = append(cars, car)
+			entries = append(entries, entry)
+		}
+	}
+
+	before := engine
+	engine.DeletedAt = &deletedAt
+	engine.Version++
+	entry, err := audit.NewEntry(ctx, models.EntityEngine, id.String(), models.OperationDelete, &before, &engine)
+	if err != nil {
+		return models.Engine{}, err
+	}
+	entries = append(entries, entry)
+
+	for _, car := range cars {
+		s.cars[car.ID] = car
+	}
+	s.engines[id] = engine
+	s.appendHistory(entries...)
+
+	return engine, nil
+}
+
+// liveEngine reports whether a car may reference the engine.
+func (s *Store) liveEngine(id uuid.UUID) bool {
+	engine, ok := s.engines[id]
+	return ok && engine.DeletedAt == nil
+}
+
+// engineOf returns the engine embedded in car the way the sql join does,
+// without the engine's own tombstone.
+func (s *Store) engineOf(car models.Car) models.Engine {
+	engine := s.engines[car.Engine.EngineId]
+	engine.DeletedAt = nil
+	return engine
+}
@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+func (s *Store) PatchCar(ctx context.Context, id string, patch models.CarPatch, expectedVersion int64) (models.Car, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	car, ok := s.cars[carId]
+	if !ok || car.DeletedAt != nil {
+		return models.Car{}, apperrors.NewNotFound("car", id)
+	}
+	if expectedVersion != 0 && car.Version != expectedVersion {
+		return models.Car{}, apperrors.NewPreconditionFailed("car", id)
+	}
+
+	before := car
+	if patch.Name != nil {
+		car.Name = *patch.Name
+	}
+	if patch.Year != nil {
+		car.Year = *patch.Year
+	}
+	if patch.Brand != nil {
+		car.Brand = *patch.Brand
+	}
+	if patch.FuelType != nil {
+		car.FuelType = *patch.FuelType
+	}
+	if patch.EngineId != nil {
+		if !s.liveEngine(*patch.EngineId) {
+			return models.Car{}, errEngineNotFound
+		}
+		car.Engine = models.Engine{EngineId: *patch.EngineId}
+	}
+	if patch.Price != nil {
+		car.Price = *patch.Price
+	}
+	car.UpdatedAt = time.Now()
+	car.Version++
+	if err := s.record(ctx, models.EntityCar, carId, models.OperationUpdate, carState(before), carState(car)); err != nil {
+		return models.Car{}, err
+	}
+	s.cars[carId] = car
+	if car.Price != before.Price {
+		s.recordPrice(carId, car.Price, car.UpdatedAt)
+	}
+
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) PatchEngine(ctx context.Context, engineId string, patch models.EnginePatch, expectedVersion int64) (models.Engine, error) {
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	engine, ok := s.engines[id]
+	if !ok || engine.DeletedAt != nil {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+	if expectedVersion != 0 && engine.Version != expectedVersion {
+		return models.Engine{}, apperrors.NewPreconditionFailed("engine", engineId)
+	}
+
+	before := engine
+	if patch.Displacement != nil {
+		engine.Displacement = *patch.Displacement
+	}
+	if patch.NoOfCylinders != nil {
+		engine.NoOfCylinders = *patch.NoOfCylinders
+	}
+	if patch.CarRange != nil {
+		engine.CarRange = *patch.CarRange
+	}
+	engine.Version++
+	if err := s.record(ctx, models.EntityEngine, id, models.OperationUpdate, &before, &engine); err != nil {
+		return models.Engine{}, err
+	}
+	s.engines[id] = engine
+
+	return engine, nil
+}
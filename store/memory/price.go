@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/TheMikeKaisen/CarManagement/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) CarPrices(ctx context.Context, id string) ([]models.PricePoint, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	car, ok := s.cars[carId]
+	if !ok || (car.DeletedAt != nil && !store.IncludeDeleted(ctx)) {
+		return nil, apperrors.NewNotFound("car", id)
+	}
+
+	prices := make([]models.PricePoint, len(s.prices[carId]))
+	copy(prices, s.prices[carId])
+	return prices, nil
+}
+
+func (s *Store) PriceStats(ctx context.Context, groupBy string) ([]models.PriceStats, error) {
+	if err := models.ValidatePriceGroupBy(groupBy); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type groupKey struct {
+		group    string
+		currency string
+	}
+
+	// Average holds the sum until every car is counted
+	groups := make(map[groupKey]*models.PriceStats)
+	for _, car := range s.cars {
+		if car.DeletedAt != nil {
+			continue
+		}
+
+		key := car.Brand
+		switch groupBy {
+		case "fuel_type":
+			key = car.FuelType
+		case "year":
+			key = car.Year
+		}
+
+		group, ok := groups[groupKey{key, car.Price.Currency}]
+		if !ok {
+			group = &models.PriceStats{Group: key, Currency: car.Price.Currency, Average: models.Money{Currency: car.Price.Currency}, Min: car.Price, Max: car.Price}
+			groups[groupKey{key, car.Price.Currency}] = group
+		}
+		group.Count++
+		group.Average.AmountMinor += car.Price.AmountMinor
+		if car.Price.AmountMinor < group.Min.AmountMinor {
+			group.Min = car.Price
+		}
+		if car.Price.AmountMinor > group.Max.AmountMinor {
+			group.Max = car.Price
+		}
+	}
+
+	stats := make([]models.PriceStats, 0, len(groups))
+	for _, group := range groups {
+		group.Average.AmountMinor = int64(math.Round(float64(group.Average.AmountMinor) / float64(group.Count)))
+		stats = append(stats, *group)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Group != stats[j].Group {
+			return stats[i].Group < stats[j].Group
+		}
+		return stats[i].Currency < stats[j].Currency
+	})
+
+	return stats, nil
+}
+
+// recordPrice closes the current price of the car and opens a new one,
+// like the car_price table of the sql store.
+func (s *Store) recordPrice(carId uuid.UUID, price models.Money, at time.Time) {
+	prices := s.prices[carId]
+	if n := len(prices); n > 0 && prices[n-1].EffectiveTo == nil {
+		prices[n-1].EffectiveTo = &at
+	}
+	s.prices[carId] = append(prices, models.PricePoint{Price: price, EffectiveFrom: at})
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheMikeKaisen/CarManagement/apperrors"
+	"github.com/TheMikeKaisen/CarManagement/models"
+	"github.com/google/uuid"
+)
+
+func (s *Store) RestoreCar(ctx context.Context, id string) (models.Car, error) {
+	carId, err := uuid.Parse(id)
+	if err != nil {
+		return models.Car{}, apperrors.NewInvalidID(id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	car, ok := s.cars[carId]
+	if !ok {
+		return models.Car{}, apperrors.NewNotFound("car", id)
+	}
+	if car.DeletedAt == nil {
+		return models.Car{}, apperrors.NewConflict("car is not deleted")
+	}
+	if car.Engine.EngineId != uuid.Nil && !s.liveEngine(car.Engine.EngineId) {
+		conflict := apperrors.NewConflict("the engine of the car is deleted, restore it first")
+		conflict.Details = map[string]any{"engine_id": car.Engine.EngineId.String()}
+		return models.Car{}, conflict
+	}
+
+	before := car
+	car.DeletedAt = nil
+	car.UpdatedAt = time.Now()
+	car.Version++
+	if err := s.record(ctx, models.EntityCar, carId, models.OperationRestore, carState(before), carState(car)); err != nil {
+		return models.Car{}, err
+	}
+	s.cars[carId] = car
+
+	car.Engine = s.engineOf(car)
+	return car, nil
+}
+
+func (s *Store) PurgeCars(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var purged int64
+	for carId, car := range s.cars {
+		if car.DeletedAt != nil && car.DeletedAt.Before(before) {
+			if err := s.record(ctx, models.EntityCar, carId, models.OperationPurge, carState(car), nil); err != nil {
+				return purged, err
+			}
+			delete(s.cars, carId)
+			delete(s.prices, carId)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (s *Store) RestoreEngine(ctx context.Context, engineId string) (models.Engine, error) {
+	id, err := uuid.Parse(engineId)
+	if err != nil {
+		return models.Engine{}, apperrors.NewInvalidID(engineId, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	engine, ok := s.engines[id]
+	if !ok {
+		return models.Engine{}, apperrors.NewNotFound("engine", engineId)
+	}
+	if engine.DeletedAt == nil {
+		return models.Engine{}, apperrors.NewConflict("engine is not deleted")
+	}
+
+	before := engine
+	engine.DeletedAt = nil
+	engine.Version++
+	if err := s.record(ctx, models.EntityEngine, id, models.OperationRestore, &before, &engine); err != nil {
+		return models.Engine{}, err
+	}
+	s.engines[id] = engine
+
+	return engine, nil
+}
+
+// PurgeEngines keeps engines still referenced by a car, like the foreign
+// key does for the sql stores.
+func (s *Store) PurgeEngines(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	referenced := make(map[uuid.UUID]bool)
+	for _, car := range s.cars {
+		referenced[car.Engine.EngineId] = true
+	}
+
+	var purged int64
+	for id, engine := range s.engines {
+		if engine.DeletedAt != nil && engine.DeletedAt.Before(before) && !referenced[id] {
+			if err := s.record(ctx, models.EntityEngine, id, models.OperationPurge, &engine, nil); err != nil {
+				return purged, err
+			}
+			delete(s.engines, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
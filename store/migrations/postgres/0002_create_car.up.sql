@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS car (
+    id         UUID PRIMARY KEY,
+    name       TEXT NOT NULL,
+    year       TEXT NOT NULL,
+    brand      TEXT NOT NULL,
+    fuel_type  TEXT NOT NULL,
+    engine_id  UUID NOT NULL REFERENCES engine (id),
+    price      NUMERIC(12, 2) NOT NULL CHECK (price > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_car_brand ON car (brand);
+CREATE INDEX IF NOT EXISTS idx_car_engine_id ON car (engine_id);
@@ -0,0 +1,2 @@
+DELETE FROM car WHERE engine_id IS NULL;
+ALTER TABLE car ALTER COLUMN engine_id SET NOT NULL;
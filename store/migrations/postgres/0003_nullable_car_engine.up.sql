@@ -0,0 +1,2 @@
+-- cars can be detached from an engine that is being deleted
+ALTER TABLE car ALTER COLUMN engine_id DROP NOT NULL;
@@ -0,0 +1,3 @@
+-- version is bumped on every write and backs the ETag of the record
+ALTER TABLE engine ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE car ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
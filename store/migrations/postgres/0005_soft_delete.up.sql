@@ -0,0 +1,6 @@
+-- deleted rows keep a tombstone until the purge job removes them
+ALTER TABLE engine ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE car ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_engine_deleted_at ON engine (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_car_deleted_at ON car (deleted_at);
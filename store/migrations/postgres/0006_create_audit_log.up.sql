@@ -0,0 +1,14 @@
+-- every write to car and engine appends an entry in the same transaction
+CREATE TABLE IF NOT EXISTS audit_log (
+    id           BIGSERIAL PRIMARY KEY,
+    entity       TEXT NOT NULL,
+    entity_id    UUID NOT NULL,
+    operation    TEXT NOT NULL,
+    actor        TEXT NOT NULL,
+    changed_at   TIMESTAMPTZ NOT NULL,
+    before_state JSONB,
+    after_state  JSONB,
+    changes      JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity, entity_id, id);
@@ -0,0 +1,15 @@
+-- every price a car has had. The row without effective_to is the current
+-- price, which is also kept in car.price.
+CREATE TABLE IF NOT EXISTS car_price (
+    id             BIGSERIAL PRIMARY KEY,
+    car_id         UUID NOT NULL REFERENCES car (id) ON DELETE CASCADE,
+    price          NUMERIC(12, 2) NOT NULL CHECK (price > 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    effective_to   TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_car_price_car_id ON car_price (car_id, effective_from);
+
+-- the history of the existing cars starts with their current price
+INSERT INTO car_price (car_id, price, effective_from)
+SELECT id, price, created_at FROM car;
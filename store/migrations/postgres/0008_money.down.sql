@@ -0,0 +1,31 @@
+-- the currency is lost: every amount is read back as cents
+UPDATE audit_log
+SET before_state = jsonb_set(before_state, '{price}', to_jsonb((before_state->'price'->>'amount_minor')::NUMERIC / 100))
+WHERE entity = 'car' AND jsonb_typeof(before_state->'price') = 'object';
+UPDATE audit_log
+SET after_state = jsonb_set(after_state, '{price}', to_jsonb((after_state->'price'->>'amount_minor')::NUMERIC / 100))
+WHERE entity = 'car' AND jsonb_typeof(after_state->'price') = 'object';
+UPDATE audit_log
+SET changes = jsonb_set(changes, '{price,from}', to_jsonb((changes->'price'->'from'->>'amount_minor')::NUMERIC / 100))
+WHERE entity = 'car' AND jsonb_typeof(changes->'price'->'from') = 'object';
+UPDATE audit_log
+SET changes = jsonb_set(changes, '{price,to}', to_jsonb((changes->'price'->'to'->>'amount_minor')::NUMERIC / 100))
+WHERE entity = 'car' AND jsonb_typeof(changes->'price'->'to') = 'object';
+
+DROP INDEX IF EXISTS idx_car_price_currency;
+
+ALTER TABLE car_price ADD COLUMN price NUMERIC(12, 2);
+UPDATE car_price SET price = price_minor / 100.0;
+ALTER TABLE car_price
+    ALTER COLUMN price SET NOT NULL,
+    ADD CONSTRAINT car_price_price_check CHECK (price > 0),
+    DROP COLUMN price_minor,
+    DROP COLUMN price_currency;
+
+ALTER TABLE car ADD COLUMN price NUMERIC(12, 2);
+UPDATE car SET price = price_minor / 100.0;
+ALTER TABLE car
+    ALTER COLUMN price SET NOT NULL,
+    ADD CONSTRAINT car_price_check CHECK (price > 0),
+    DROP COLUMN price_minor,
+    DROP COLUMN price_currency;
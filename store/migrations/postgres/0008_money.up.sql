@@ -0,0 +1,39 @@
+-- prices become an amount in minor units plus an ISO 4217 currency. The
+-- prices stored so far had no currency and are taken to be US dollars.
+ALTER TABLE car ADD COLUMN price_minor BIGINT;
+ALTER TABLE car ADD COLUMN price_currency CHAR(3);
+UPDATE car SET price_minor = ROUND(price * 100), price_currency = 'USD';
+ALTER TABLE car
+    ALTER COLUMN price_minor SET NOT NULL,
+    ALTER COLUMN price_currency SET NOT NULL,
+    ADD CONSTRAINT car_price_minor_check CHECK (price_minor > 0),
+    DROP COLUMN price;
+
+ALTER TABLE car_price ADD COLUMN price_minor BIGINT;
+ALTER TABLE car_price ADD COLUMN price_currency CHAR(3);
+UPDATE car_price SET price_minor = ROUND(price * 100), price_currency = 'USD';
+ALTER TABLE car_price
+    ALTER COLUMN price_minor SET NOT NULL,
+    ALTER COLUMN price_currency SET NOT NULL,
+    ADD CONSTRAINT car_price_price_minor_check CHECK (price_minor > 0),
+    DROP COLUMN price;
+
+CREATE INDEX IF NOT EXISTS idx_car_price_currency ON car (price_currency, price_minor);
+
+-- the car states in the audit log follow, so as_of reads keep working
+UPDATE audit_log
+SET before_state = jsonb_set(before_state, '{price}', jsonb_build_object(
+        'amount_minor', ROUND((before_state->>'price')::NUMERIC * 100), 'currency', 'USD'))
+WHERE entity = 'car' AND jsonb_typeof(before_state->'price') = 'number';
+UPDATE audit_log
+SET after_state = jsonb_set(after_state, '{price}', jsonb_build_object(
+        'amount_minor', ROUND((after_state->>'price')::NUMERIC * 100), 'currency', 'USD'))
+WHERE entity = 'car' AND jsonb_typeof(after_state->'price') = 'number';
+UPDATE audit_log
+SET changes = jsonb_set(changes, '{price,from}', jsonb_build_object(
+        'amount_minor', ROUND((changes->'price'->>'from')::NUMERIC * 100), 'currency', 'USD'))
+WHERE entity = 'car' AND jsonb_typeof(changes->'price'->'from') = 'number';
+UPDATE audit_log
+SET changes = jsonb_set(changes, '{price,to}', jsonb_build_object(
+        'amount_minor', ROUND((changes->'price'->>'to')::NUMERIC * 100), 'currency', 'USD'))
+WHERE entity = 'car' AND jsonb_typeof(changes->'price'->'to') = 'number';
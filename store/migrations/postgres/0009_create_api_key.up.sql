@@ -0,0 +1,11 @@
+-- api keys are only kept as a sha-256 hash, the key itself is shown once
+-- when it is created. prefix is the start of the key, to tell keys apart.
+CREATE TABLE IF NOT EXISTS api_key (
+    id         UUID PRIMARY KEY,
+    name       TEXT NOT NULL,
+    prefix     TEXT NOT NULL,
+    hash       TEXT NOT NULL UNIQUE,
+    roles      TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+-- postgres keeps timestamptz values, only the sqlite times needed rewriting
+SELECT 1;
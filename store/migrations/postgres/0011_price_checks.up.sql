@@ -0,0 +1,2 @@
+-- postgres has checked the prices since 0008_money, only sqlite needed them
+SELECT 1;
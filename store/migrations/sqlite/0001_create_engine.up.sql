@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS engine (
+    id              TEXT PRIMARY KEY,
+    displacement    INTEGER NOT NULL CHECK (displacement > 0),
+    no_of_cylinders INTEGER NOT NULL CHECK (no_of_cylinders > 0),
+    car_range       INTEGER NOT NULL CHECK (car_range > 0)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS car (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    year       TEXT NOT NULL,
+    brand      TEXT NOT NULL,
+    fuel_type  TEXT NOT NULL,
+    engine_id  TEXT NOT NULL REFERENCES engine (id),
+    price      REAL NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_car_brand ON car (brand);
+CREATE INDEX IF NOT EXISTS idx_car_engine_id ON car (engine_id);
@@ -0,0 +1,20 @@
+-- cars can be detached from an engine that is being deleted. sqlite cannot
+-- drop a NOT NULL constraint, so the table is rebuilt.
+CREATE TABLE car_new (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    year       TEXT NOT NULL,
+    brand      TEXT NOT NULL,
+    fuel_type  TEXT NOT NULL,
+    engine_id  TEXT REFERENCES engine (id),
+    price      REAL NOT NULL CHECK (price > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO car_new SELECT id, name, year, brand, fuel_type, engine_id, price, created_at, updated_at FROM car;
+DROP TABLE car;
+ALTER TABLE car_new RENAME TO car;
+
+CREATE INDEX IF NOT EXISTS idx_car_brand ON car (brand);
+CREATE INDEX IF NOT EXISTS idx_car_engine_id ON car (engine_id);
@@ -0,0 +1,2 @@
+ALTER TABLE car DROP COLUMN version;
+ALTER TABLE engine DROP COLUMN version;
@@ -0,0 +1,14 @@
+-- every write to car and engine appends an entry in the same transaction
+CREATE TABLE IF NOT EXISTS audit_log (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity       TEXT NOT NULL,
+    entity_id    TEXT NOT NULL,
+    operation    TEXT NOT NULL,
+    actor        TEXT NOT NULL,
+    changed_at   TIMESTAMP NOT NULL,
+    before_state TEXT,
+    after_state  TEXT,
+    changes      TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity, entity_id, id);
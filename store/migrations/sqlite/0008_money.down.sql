@@ -0,0 +1,25 @@
+-- the currency is lost: every amount is read back as cents
+UPDATE audit_log
+SET before_state = json_set(before_state, '$.price', json_extract(before_state, '$.price.amount_minor') / 100.0)
+WHERE entity = 'car' AND json_type(before_state, '$.price') = 'object';
+UPDATE audit_log
+SET after_state = json_set(after_state, '$.price', json_extract(after_state, '$.price.amount_minor') / 100.0)
+WHERE entity = 'car' AND json_type(after_state, '$.price') = 'object';
+UPDATE audit_log
+SET changes = json_set(changes, '$.price.from', json_extract(changes, '$.price.from.amount_minor') / 100.0)
+WHERE entity = 'car' AND json_type(changes, '$.price.from') = 'object';
+UPDATE audit_log
+SET changes = json_set(changes, '$.price.to', json_extract(changes, '$.price.to.amount_minor') / 100.0)
+WHERE entity = 'car' AND json_type(changes, '$.price.to') = 'object';
+
+DROP INDEX IF EXISTS idx_car_price_currency;
+
+ALTER TABLE car_price ADD COLUMN price REAL NOT NULL DEFAULT 0;
+UPDATE car_price SET price = price_minor / 100.0;
+ALTER TABLE car_price DROP COLUMN price_minor;
+ALTER TABLE car_price DROP COLUMN price_currency;
+
+ALTER TABLE car ADD COLUMN price REAL NOT NULL DEFAULT 0;
+UPDATE car SET price = price_minor / 100.0;
+ALTER TABLE car DROP COLUMN price_minor;
+ALTER TABLE car DROP COLUMN price_currency;
@@ -0,0 +1,33 @@
+-- prices become an amount in minor units plus an ISO 4217 currency. The
+-- prices stored so far had no currency and are taken to be US dollars.
+-- sqlite can only add a NOT NULL column with a default, which the update
+-- replaces right away.
+ALTER TABLE car ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE car ADD COLUMN price_currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE car SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE car DROP COLUMN price;
+
+ALTER TABLE car_price ADD COLUMN price_minor INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE car_price ADD COLUMN price_currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE car_price SET price_minor = CAST(ROUND(price * 100) AS INTEGER);
+ALTER TABLE car_price DROP COLUMN price;
+
+CREATE INDEX IF NOT EXISTS idx_car_price_currency ON car (price_currency, price_minor);
+
+-- the car states in the audit log follow, so as_of reads keep working
+UPDATE audit_log
+SET before_state = json_set(before_state, '$.price', json_object(
+        'amount_minor', CAST(ROUND(json_extract(before_state, '$.price') * 100) AS INTEGER), 'currency', 'USD'))
+WHERE entity = 'car' AND json_type(before_state, '$.price') IN ('integer', 'real');
+UPDATE audit_log
+SET after_state = json_set(after_state, '$.price', json_object(
+        'amount_minor', CAST(ROUND(json_extract(after_state, '$.price') * 100) AS INTEGER), 'currency', 'USD'))
+WHERE entity = 'car' AND json_type(after_state, '$.price') IN ('integer', 'real');
+UPDATE audit_log
+SET changes = json_set(changes, '$.price.from', json_object(
+        'amount_minor', CAST(ROUND(json_extract(changes, '$.price.from') * 100) AS INTEGER), 'currency', 'USD'))
+WHERE entity = 'car' AND json_type(changes, '$.price.from') IN ('integer', 'real');
+UPDATE audit_log
+SET changes = json_set(changes, '$.price.to', json_object(
+        'amount_minor', CAST(ROUND(json_extract(changes, '$.price.to') * 100) AS INTEGER), 'currency', 'USD'))
+WHERE entity = 'car' AND json_type(changes, '$.price.to') IN ('integer', 'real');
@@ -0,0 +1,3 @@
+-- the old format cannot be written back, the times stay as they are. The
+-- driver reads both formats.
+SELECT 1;
@@ -0,0 +1,59 @@
+-- times used to be written with time.Time.String, e.g.
+-- "2024-05-01 10:00:00.5 +0200 CEST m=+0.01", which does not compare like
+-- the times it holds. They are rewritten as "2024-05-01 08:00:00.5+00:00",
+-- the format written from now on: in UTC, with the same fraction of a second.
+-- Times written by CURRENT_TIMESTAMP are already UTC and only get the offset.
+
+UPDATE engine
+SET deleted_at = datetime(substr(deleted_at, 1, 19) || substr(deleted_at, instr(substr(deleted_at, 20), ' ') + 20, 3) || ':' || substr(deleted_at, instr(substr(deleted_at, 20), ' ') + 23, 2))
+    || substr(deleted_at, 20, instr(substr(deleted_at, 20), ' ') - 1) || '+00:00'
+WHERE deleted_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE engine SET deleted_at = deleted_at || '+00:00' WHERE deleted_at GLOB '????-??-?? ??:??:??';
+
+UPDATE car
+SET created_at = datetime(substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2))
+    || substr(created_at, 20, instr(substr(created_at, 20), ' ') - 1) || '+00:00'
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE car SET created_at = created_at || '+00:00' WHERE created_at GLOB '????-??-?? ??:??:??';
+
+UPDATE car
+SET updated_at = datetime(substr(updated_at, 1, 19) || substr(updated_at, instr(substr(updated_at, 20), ' ') + 20, 3) || ':' || substr(updated_at, instr(substr(updated_at, 20), ' ') + 23, 2))
+    || substr(updated_at, 20, instr(substr(updated_at, 20), ' ') - 1) || '+00:00'
+WHERE updated_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE car SET updated_at = updated_at || '+00:00' WHERE updated_at GLOB '????-??-?? ??:??:??';
+
+UPDATE car
+SET deleted_at = datetime(substr(deleted_at, 1, 19) || substr(deleted_at, instr(substr(deleted_at, 20), ' ') + 20, 3) || ':' || substr(deleted_at, instr(substr(deleted_at, 20), ' ') + 23, 2))
+    || substr(deleted_at, 20, instr(substr(deleted_at, 20), ' ') - 1) || '+00:00'
+WHERE deleted_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE car SET deleted_at = deleted_at || '+00:00' WHERE deleted_at GLOB '????-??-?? ??:??:??';
+
+UPDATE audit_log
+SET changed_at = datetime(substr(changed_at, 1, 19) || substr(changed_at, instr(substr(changed_at, 20), ' ') + 20, 3) || ':' || substr(changed_at, instr(substr(changed_at, 20), ' ') + 23, 2))
+    || substr(changed_at, 20, instr(substr(changed_at, 20), ' ') - 1) || '+00:00'
+WHERE changed_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE audit_log SET changed_at = changed_at || '+00:00' WHERE changed_at GLOB '????-??-?? ??:??:??';
+
+UPDATE car_price
+SET effective_from = datetime(substr(effective_from, 1, 19) || substr(effective_from, instr(substr(effective_from, 20), ' ') + 20, 3) || ':' || substr(effective_from, instr(substr(effective_from, 20), ' ') + 23, 2))
+    || substr(effective_from, 20, instr(substr(effective_from, 20), ' ') - 1) || '+00:00'
+WHERE effective_from GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE car_price SET effective_from = effective_from || '+00:00' WHERE effective_from GLOB '????-??-?? ??:??:??';
+
+UPDATE car_price
+SET effective_to = datetime(substr(effective_to, 1, 19) || substr(effective_to, instr(substr(effective_to, 20), ' ') + 20, 3) || ':' || substr(effective_to, instr(substr(effective_to, 20), ' ') + 23, 2))
+    || substr(effective_to, 20, instr(substr(effective_to, 20), ' ') - 1) || '+00:00'
+WHERE effective_to GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE car_price SET effective_to = effective_to || '+00:00' WHERE effective_to GLOB '????-??-?? ??:??:??';
+
+UPDATE api_key
+SET created_at = datetime(substr(created_at, 1, 19) || substr(created_at, instr(substr(created_at, 20), ' ') + 20, 3) || ':' || substr(created_at, instr(substr(created_at, 20), ' ') + 23, 2))
+    || substr(created_at, 20, instr(substr(created_at, 20), ' ') - 1) || '+00:00'
+WHERE created_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE api_key SET created_at = created_at || '+00:00' WHERE created_at GLOB '????-??-?? ??:??:??';
+
+UPDATE api_key
+SET revoked_at = datetime(substr(revoked_at, 1, 19) || substr(revoked_at, instr(substr(revoked_at, 20), ' ') + 20, 3) || ':' || substr(revoked_at, instr(substr(revoked_at, 20), ' ') + 23, 2))
+    || substr(revoked_at, 20, instr(substr(revoked_at, 20), ' ') - 1) || '+00:00'
+WHERE revoked_at GLOB '????-??-?? ??:??:??* [+-][0-9][0-9][0-9][0-9] *';
+UPDATE api_key SET revoked_at = revoked_at || '+00:00' WHERE revoked_at GLOB '????-??-?? ??:??:??';
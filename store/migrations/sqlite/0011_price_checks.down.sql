@@ -0,0 +1,42 @@
+CREATE TEMP TABLE car_price_saved AS SELECT * FROM car_price;
+DROP TABLE car_price;
+
+CREATE TABLE car_old (
+    id             TEXT PRIMARY KEY,
+    name           TEXT NOT NULL,
+    year           TEXT NOT NULL,
+    brand          TEXT NOT NULL,
+    fuel_type      TEXT NOT NULL,
+    engine_id      TEXT REFERENCES engine (id),
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    version        INTEGER NOT NULL DEFAULT 1,
+    deleted_at     TIMESTAMP,
+    price_minor    INTEGER NOT NULL DEFAULT 0,
+    price_currency TEXT NOT NULL DEFAULT 'USD'
+);
+
+INSERT INTO car_old (id, name, year, brand, fuel_type, engine_id, created_at, updated_at, version, deleted_at, price_minor, price_currency)
+SELECT id, name, year, brand, fuel_type, engine_id, created_at, updated_at, version, deleted_at, price_minor, price_currency FROM car;
+DROP TABLE car;
+ALTER TABLE car_old RENAME TO car;
+
+CREATE INDEX IF NOT EXISTS idx_car_brand ON car (brand);
+CREATE INDEX IF NOT EXISTS idx_car_engine_id ON car (engine_id);
+CREATE INDEX IF NOT EXISTS idx_car_deleted_at ON car (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_car_price_currency ON car (price_currency, price_minor);
+
+CREATE TABLE car_price (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    car_id         TEXT NOT NULL REFERENCES car (id) ON DELETE CASCADE,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to   TIMESTAMP,
+    price_minor    INTEGER NOT NULL DEFAULT 0,
+    price_currency TEXT NOT NULL DEFAULT 'USD'
+);
+
+INSERT INTO car_price (id, car_id, effective_from, effective_to, price_minor, price_currency)
+SELECT id, car_id, effective_from, effective_to, price_minor, price_currency FROM car_price_saved;
+DROP TABLE car_price_saved;
+
+CREATE INDEX IF NOT EXISTS idx_car_price_car_id ON car_price (car_id, effective_from);
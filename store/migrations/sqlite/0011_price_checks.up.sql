@@ -0,0 +1,46 @@
+-- prices have to be positive, as the CHECK constraints of postgres ask.
+-- sqlite cannot add a constraint to a column, so car and car_price are
+-- rebuilt. Dropping car would cascade to the price history, which is kept
+-- aside meanwhile.
+CREATE TEMP TABLE car_price_saved AS SELECT * FROM car_price;
+DROP TABLE car_price;
+
+CREATE TABLE car_new (
+    id             TEXT PRIMARY KEY,
+    name           TEXT NOT NULL,
+    year           TEXT NOT NULL,
+    brand          TEXT NOT NULL,
+    fuel_type      TEXT NOT NULL,
+    engine_id      TEXT REFERENCES engine (id),
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    version        INTEGER NOT NULL DEFAULT 1,
+    deleted_at     TIMESTAMP,
+    price_minor    INTEGER NOT NULL CHECK (price_minor > 0),
+    price_currency TEXT NOT NULL
+);
+
+INSERT INTO car_new (id, name, year, brand, fuel_type, engine_id, created_at, updated_at, version, deleted_at, price_minor, price_currency)
+SELECT id, name, year, brand, fuel_type, engine_id, created_at, updated_at, version, deleted_at, price_minor, price_currency FROM car;
+DROP TABLE car;
+ALTER TABLE car_new RENAME TO car;
+
+CREATE INDEX IF NOT EXISTS idx_car_brand ON car (brand);
+CREATE INDEX IF NOT EXISTS idx_car_engine_id ON car (engine_id);
+CREATE INDEX IF NOT EXISTS idx_car_deleted_at ON car (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_car_price_currency ON car (price_currency, price_minor);
+
+CREATE TABLE car_price (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    car_id         TEXT NOT NULL REFERENCES car (id) ON DELETE CASCADE,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to   TIMESTAMP,
+    price_minor    INTEGER NOT NULL CHECK (price_minor > 0),
+    price_currency TEXT NOT NULL
+);
+
+INSERT INTO car_price (id, car_id, effective_from, effective_to, price_minor, price_currency)
+SELECT id, car_id, effective_from, effective_to, price_minor, price_currency FROM car_price_saved;
+DROP TABLE car_price_saved;
+
+CREATE INDEX IF NOT EXISTS idx_car_price_car_id ON car_price (car_id, effective_from);